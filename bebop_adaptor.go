@@ -1,6 +1,9 @@
 package bebop
 
 import (
+	"context"
+	"io"
+
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/platforms/parrot/bebop/client"
 )
@@ -26,6 +29,87 @@ type drone interface {
 	Outdoor(outdoor bool) error
 	VideoEnable(enable bool) error
 	VideoStreamMode(mode int8) error
+	CameraOrientation(tilt int8, pan int8) error
+	CameraOrientationV2(tilt float32, pan float32) error
+	CameraVelocity(tilt float32, pan float32) error
+	CameraState() client.CameraState
+	TakePicture() error
+	TakePictureAndWait(ctx context.Context) (client.PictureEvent, error)
+	PictureState() client.PictureState
+	VideoState() client.VideoState
+	PilotingSettings() client.PilotingSettings
+	SetMaxAltitude(altitude float32) (client.FloatSetting, error)
+	SetMaxTilt(tilt float32) (client.FloatSetting, error)
+	SetMaxDistance(distance float32) (client.FloatSetting, error)
+	SetAbsolutControl(on bool) (bool, error)
+	SetNoFlyOverMaxDistance(on bool) (bool, error)
+	SetBankedTurn(on bool) (bool, error)
+	SpeedSettings() client.SpeedSettings
+	SetMaxVerticalSpeed(speed float32) (client.FloatSetting, error)
+	SetMaxRotationSpeed(speed float32) (client.FloatSetting, error)
+	SetMaxPitchRollRotationSpeed(speed float32) (client.FloatSetting, error)
+	SpeedProfile() client.SpeedProfile
+	SetSpeedProfile(p client.SpeedProfile) error
+	WifiScan(ctx context.Context, band byte) ([]client.WifiNetwork, error)
+	AuthorizedChannels(ctx context.Context) ([]client.WifiChannel, error)
+	WifiSelection(selection byte, band byte, channel byte) (client.WifiSelection, error)
+	WifiSelectionState() client.WifiSelection
+	AutoSelectWifiChannel(ctx context.Context, band byte, outdoor bool) (client.WifiSelection, error)
+	PictureSettings() client.PictureSettings
+	SetPictureSettings(s client.PictureSettings) error
+	SetPictureFormat(format byte) (byte, error)
+	SetAutoWhiteBalance(mode byte) (byte, error)
+	SetExposition(value float32) (client.FloatSetting, error)
+	SetSaturation(value float32) (client.FloatSetting, error)
+	SetTimelapse(enabled bool, interval float32) (client.FloatSetting, error)
+	SetVideoAutorecord(enabled bool) (bool, error)
+	SetVideoStabilization(mode byte) (byte, error)
+	SetVideoRecordingMode(mode byte) (byte, error)
+	SetVideoFramerate(framerate byte) (byte, error)
+	SetVideoResolution(resolution byte) (byte, error)
+	ElectricFrequency(frequency byte) (byte, error)
+	AntiflickeringMode(mode byte) (byte, error)
+	AntiflickeringState() client.Antiflickering
+	GPSSettings() client.GPSSettings
+	SetHome(latitude float64, longitude float64, altitude float64) (client.Location, error)
+	ResetHome() (client.Location, error)
+	HomeType(homeType byte) (byte, error)
+	ReturnHomeDelay(delay uint16) (uint16, error)
+	SendControllerGPS(latitude float64, longitude float64, altitude float64, horizontalAccuracy float64, verticalAccuracy float64) error
+	StartMagnetoCalibration() error
+	AbortMagnetoCalibration() error
+	MagnetoCalibrationState() client.MagnetoCalibration
+	NextMagnetoCalibrationStep(ctx context.Context, previous client.MagnetoCalibration) (client.MagnetoCalibration, error)
+	UploadFlightPlan(ctx context.Context, plan io.Reader) error
+	StartFlightPlan() error
+	PauseFlightPlan() error
+	StopFlightPlan() error
+	FlightPlanState() client.FlightPlan
+	UploadMission(ctx context.Context, m *client.Mission) error
+	Storage() client.Storage
+	StorageFull() <-chan client.Storage
+	Product() client.Product
+	AllSettings(ctx context.Context) (client.Settings, error)
+	SettingsReset() error
+	SetProductName(name string) (string, error)
+	SetCountry(code string) (string, error)
+	AutoCountry(automatic bool) (bool, error)
+	WifiOutdoor(outdoor bool) (bool, error)
+	FlyingState() byte
+	Overheat() client.Overheat
+	Overheated() <-chan client.Overheat
+	SwitchOff() error
+	Ventilate() error
+	IsPiloting(piloting bool) error
+	Reboot(ctx context.Context) error
+	State() client.State
+	Subscribe() (<-chan client.StateChange, func())
+	WaitFlyingState(ctx context.Context, states ...byte) (byte, error)
+	TakeOffAndWait(ctx context.Context) error
+	LandAndWait(ctx context.Context) error
+	SetAlertPolicy(alert byte, action client.AlertAction)
+	Alerts() (<-chan client.Alert, func())
+	NavigateHome(start bool) error
 }
 
 // Adaptor is gobot.Adaptor representation for the Bebop
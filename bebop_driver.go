@@ -1,18 +1,85 @@
 package bebop
 
 import (
+	"context"
+	"io"
+
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/platforms/parrot/bebop/client"
 )
 
 const (
+	// TakingOff event
+	TakingOff = "takingoff"
+
+	// Hovering event
+	Hovering = "hovering"
+
 	// Flying event
 	Flying = "flying"
+
+	// Landing event
+	Landing = "landing"
+
+	// Landed event
+	Landed = "landed"
+
+	// Emergency event
+	Emergency = "emergency"
+
+	// Battery event, its data is the charge left in percent
+	Battery = "battery"
+
+	// Alert event, its data is one of
+	// client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_*
+	Alert = "alert"
+
+	// AlertHandled event, its data is the client.Alert whose action ran
+	AlertHandled = "alertHandled"
+
+	// Position event, its data is a client.Location
+	Position = "position"
+
+	// Attitude event, its data is a client.Attitude
+	Attitude = "attitude"
+
+	// Altitude event, its data is the altitude in meters
+	Altitude = "altitude"
+
+	// Recording event, its data is true while recording a video
+	Recording = "recording"
+
+	// Connected event
+	Connected = "connected"
+
+	// Disconnected event
+	Disconnected = "disconnected"
+
+	// StorageFull event
+	StorageFull = "storageFull"
+
+	// Overheat event
+	Overheat = "overheat"
 )
 
+// flyingStateEvents are the events published when the drone enters a
+// flying state.
+var flyingStateEvents = map[byte]string{
+	client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_LANDED:    Landed,
+	client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_TAKINGOFF: TakingOff,
+	client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_HOVERING:  Hovering,
+	client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_FLYING:    Flying,
+	client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_LANDING:   Landing,
+	client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_EMERGENCY: Emergency,
+}
+
 // Driver is gobot.Driver representation for the Bebop
 type Driver struct {
-	name       string
-	connection gobot.Connection
+	name        string
+	connection  gobot.Connection
+	unsubscribe func()
+	done        chan struct{}
+	stopped     chan struct{}
 	gobot.Eventer
 }
 
@@ -23,7 +90,13 @@ func NewDriver(connection *Adaptor) *Driver {
 		connection: connection,
 		Eventer:    gobot.NewEventer(),
 	}
-	d.AddEvent(Flying)
+	for _, event := range []string{
+		TakingOff, Hovering, Flying, Landing, Landed, Emergency,
+		Battery, Alert, AlertHandled, Position, Attitude, Altitude,
+		Recording, Connected, Disconnected, StorageFull, Overheat,
+	} {
+		d.AddEvent(event)
+	}
 	return d
 }
 
@@ -41,24 +114,127 @@ func (a *Driver) adaptor() *Adaptor {
 	return a.Connection().(*Adaptor)
 }
 
-// Start starts the Bebop Driver
+// Start starts the Bebop Driver, it does nothing when already started
 func (a *Driver) Start() (err error) {
+	if a.done != nil {
+		return
+	}
+
+	drone := a.adaptor().drone
+
+	changes, unsubscribeState := drone.Subscribe()
+	alerts, unsubscribeAlerts := drone.Alerts()
+	a.unsubscribe = func() {
+		unsubscribeState()
+		unsubscribeAlerts()
+	}
+	a.done, a.stopped = make(chan struct{}), make(chan struct{})
+
+	go func(done chan struct{}, stopped chan struct{}) {
+		defer close(stopped)
+		storageFull, overheated := drone.StorageFull(), drone.Overheated()
+		for {
+			select {
+			case <-done:
+				return
+			case change, ok := <-changes:
+				if !ok {
+					changes = nil
+					continue
+				}
+				a.publishState(change)
+			case s := <-storageFull:
+				a.Publish(a.Event(StorageFull), s)
+			case o := <-overheated:
+				a.Publish(a.Event(Overheat), o)
+			case alert, ok := <-alerts:
+				if !ok {
+					alerts = nil
+					continue
+				}
+				a.Publish(a.Event(AlertHandled), alert)
+			}
+		}
+	}(a.done, a.stopped)
 	return
 }
 
+// publishState publishes the events of the fields of the state that
+// changed.
+func (a *Driver) publishState(change client.StateChange) {
+	state := change.State
+
+	if change.Changed.Has(client.StateFlyingState) {
+		if event, ok := flyingStateEvents[state.FlyingState]; ok {
+			a.Publish(a.Event(event), state.FlyingState)
+		}
+	}
+	if change.Changed.Has(client.StateBattery) {
+		a.Publish(a.Event(Battery), state.Battery)
+	}
+	if change.Changed.Has(client.StateAlert) {
+		a.Publish(a.Event(Alert), state.Alert)
+	}
+	if change.Changed.Has(client.StatePosition) {
+		a.Publish(a.Event(Position), state.Position)
+	}
+	if change.Changed.Has(client.StateAttitude) {
+		a.Publish(a.Event(Attitude), state.Attitude)
+	}
+	if change.Changed.Has(client.StateAltitude) {
+		a.Publish(a.Event(Altitude), state.Altitude)
+	}
+	if change.Changed.Has(client.StateRecording) {
+		a.Publish(a.Event(Recording), state.Recording)
+	}
+	if change.Changed.Has(client.StateConnected) {
+		if state.Connected {
+			a.Publish(a.Event(Connected), nil)
+		} else {
+			a.Publish(a.Event(Disconnected), nil)
+		}
+	}
+}
+
 // Halt halts the Bebop Driver
 func (a *Driver) Halt() (err error) {
+	if a.unsubscribe != nil {
+		a.unsubscribe()
+		a.unsubscribe = nil
+	}
+	if a.done != nil {
+		close(a.done)
+		<-a.stopped
+		a.done, a.stopped = nil, nil
+	}
 	return
 }
 
-// TakeOff makes the drone start flying
-func (a *Driver) TakeOff() {
-	a.Publish(a.Event("flying"), a.adaptor().drone.TakeOff())
+// TakeOff makes the drone start flying, the TakingOff and then Hovering
+// events are published as the drone reports them
+func (a *Driver) TakeOff() error {
+	return a.adaptor().drone.TakeOff()
+}
+
+// TakeOffAndWait makes the drone take off and blocks until it hovers
+func (a *Driver) TakeOffAndWait(ctx context.Context) error {
+	return a.adaptor().drone.TakeOffAndWait(ctx)
 }
 
 // Land causes the drone to land
-func (a *Driver) Land() {
-	a.adaptor().drone.Land()
+func (a *Driver) Land() error {
+	return a.adaptor().drone.Land()
+}
+
+// LandAndWait makes the drone land and blocks until it is landed
+func (a *Driver) LandAndWait(ctx context.Context) error {
+	return a.adaptor().drone.LandAndWait(ctx)
+}
+
+// WaitFlyingState blocks until the drone reports one of states and returns
+// the flying state reached
+func (a *Driver) WaitFlyingState(ctx context.Context, states ...byte) (byte, error) {
+	return a.adaptor().drone.WaitFlyingState(ctx, states...)
 }
 
 // Up makes the drone gain altitude.
@@ -121,7 +297,8 @@ func (a *Driver) Video() chan []byte {
 	return a.adaptor().drone.Video()
 }
 
-// StartRecording starts the recording video to the drones interal storage
+// StartRecording starts the recording video to the drones interal storage.
+// An error is returned if the drone did not start recording.
 func (a *Driver) StartRecording() error {
 	return a.adaptor().drone.StartRecording()
 }
@@ -150,3 +327,381 @@ func (a *Driver) VideoEnable(enable bool) error {
 func (a *Driver) VideoStreamMode(mode int8) error {
 	return a.adaptor().drone.VideoStreamMode(mode)
 }
+
+// CameraOrientation points the camera using whole degrees for tilt and pan
+func (a *Driver) CameraOrientation(tilt int8, pan int8) error {
+	return a.adaptor().drone.CameraOrientation(tilt, pan)
+}
+
+// CameraOrientationV2 points the camera, tilt and pan are in degrees. A negative tilt looks down.
+func (a *Driver) CameraOrientationV2(tilt float32, pan float32) error {
+	return a.adaptor().drone.CameraOrientationV2(tilt, pan)
+}
+
+// CameraVelocity moves the camera at the given tilt and pan speed in degrees per second
+func (a *Driver) CameraVelocity(tilt float32, pan float32) error {
+	return a.adaptor().drone.CameraVelocity(tilt, pan)
+}
+
+// CameraState returns the camera orientation last reported by the drone
+func (a *Driver) CameraState() client.CameraState {
+	return a.adaptor().drone.CameraState()
+}
+
+// TakePicture takes a picture and saves it to the drones internal storage
+func (a *Driver) TakePicture() error {
+	return a.adaptor().drone.TakePicture()
+}
+
+// TakePictureAndWait takes a picture and waits until the drone has saved it or ctx is done
+func (a *Driver) TakePictureAndWait(ctx context.Context) (client.PictureEvent, error) {
+	return a.adaptor().drone.TakePictureAndWait(ctx)
+}
+
+// PictureState returns whether the drone is ready to take a picture
+func (a *Driver) PictureState() client.PictureState {
+	return a.adaptor().drone.PictureState()
+}
+
+// VideoState returns whether the drone is recording video
+func (a *Driver) VideoState() client.VideoState {
+	return a.adaptor().drone.VideoState()
+}
+
+// PilotingSettings returns the flight limits last reported by the drone
+func (a *Driver) PilotingSettings() client.PilotingSettings {
+	return a.adaptor().drone.PilotingSettings()
+}
+
+// SetMaxAltitude sets the maximum altitude in meters and returns the value confirmed by the drone
+func (a *Driver) SetMaxAltitude(altitude float32) (client.FloatSetting, error) {
+	return a.adaptor().drone.SetMaxAltitude(altitude)
+}
+
+// SetMaxTilt sets the maximum tilt in degrees and returns the value confirmed by the drone
+func (a *Driver) SetMaxTilt(tilt float32) (client.FloatSetting, error) {
+	return a.adaptor().drone.SetMaxTilt(tilt)
+}
+
+// SetMaxDistance sets the maximum distance from home in meters and returns the value confirmed by the drone
+func (a *Driver) SetMaxDistance(distance float32) (client.FloatSetting, error) {
+	return a.adaptor().drone.SetMaxDistance(distance)
+}
+
+// SetAbsolutControl enables or disables absolute control
+func (a *Driver) SetAbsolutControl(on bool) (bool, error) {
+	return a.adaptor().drone.SetAbsolutControl(on)
+}
+
+// SetNoFlyOverMaxDistance keeps the drone within the maximum distance from home
+func (a *Driver) SetNoFlyOverMaxDistance(on bool) (bool, error) {
+	return a.adaptor().drone.SetNoFlyOverMaxDistance(on)
+}
+
+// SetBankedTurn enables or disables banked turns
+func (a *Driver) SetBankedTurn(on bool) (bool, error) {
+	return a.adaptor().drone.SetBankedTurn(on)
+}
+
+// SpeedSettings returns the speed limits last reported by the drone
+func (a *Driver) SpeedSettings() client.SpeedSettings {
+	return a.adaptor().drone.SpeedSettings()
+}
+
+// SetMaxVerticalSpeed sets the maximum vertical speed in m/s and returns the value confirmed by the drone
+func (a *Driver) SetMaxVerticalSpeed(speed float32) (client.FloatSetting, error) {
+	return a.adaptor().drone.SetMaxVerticalSpeed(speed)
+}
+
+// SetMaxRotationSpeed sets the maximum yaw rotation speed in degrees/s and returns the value confirmed by the drone
+func (a *Driver) SetMaxRotationSpeed(speed float32) (client.FloatSetting, error) {
+	return a.adaptor().drone.SetMaxRotationSpeed(speed)
+}
+
+// SetMaxPitchRollRotationSpeed sets the maximum pitch/roll rotation speed in degrees/s and returns the value confirmed by the drone
+func (a *Driver) SetMaxPitchRollRotationSpeed(speed float32) (client.FloatSetting, error) {
+	return a.adaptor().drone.SetMaxPitchRollRotationSpeed(speed)
+}
+
+// SpeedProfile returns the current speed profile of the drone
+func (a *Driver) SpeedProfile() client.SpeedProfile {
+	return a.adaptor().drone.SpeedProfile()
+}
+
+// SetSpeedProfile switches all settings of the speed profile at once, restoring the previous profile on failure
+func (a *Driver) SetSpeedProfile(p client.SpeedProfile) error {
+	return a.adaptor().drone.SetSpeedProfile(p)
+}
+
+// WifiScan asks the drone to scan a band for Wi-Fi networks
+func (a *Driver) WifiScan(ctx context.Context, band byte) ([]client.WifiNetwork, error) {
+	return a.adaptor().drone.WifiScan(ctx, band)
+}
+
+// AuthorizedChannels returns the Wi-Fi channels the drone is allowed to use
+func (a *Driver) AuthorizedChannels(ctx context.Context) ([]client.WifiChannel, error) {
+	return a.adaptor().drone.AuthorizedChannels(ctx)
+}
+
+// WifiSelection sets how the drone picks its Wi-Fi band and channel
+func (a *Driver) WifiSelection(selection byte, band byte, channel byte) (client.WifiSelection, error) {
+	return a.adaptor().drone.WifiSelection(selection, band, channel)
+}
+
+// WifiSelectionState returns the Wi-Fi selection last reported by the drone
+func (a *Driver) WifiSelectionState() client.WifiSelection {
+	return a.adaptor().drone.WifiSelectionState()
+}
+
+// AutoSelectWifiChannel moves the drone to the least congested authorized channel of a band
+func (a *Driver) AutoSelectWifiChannel(ctx context.Context, band byte, outdoor bool) (client.WifiSelection, error) {
+	return a.adaptor().drone.AutoSelectWifiChannel(ctx, band, outdoor)
+}
+
+// PictureSettings returns the camera settings last reported by the drone
+func (a *Driver) PictureSettings() client.PictureSettings {
+	return a.adaptor().drone.PictureSettings()
+}
+
+// SetPictureSettings applies a complete set of camera settings, such as one saved from PictureSettings
+func (a *Driver) SetPictureSettings(s client.PictureSettings) error {
+	return a.adaptor().drone.SetPictureSettings(s)
+}
+
+// SetPictureFormat sets the format of the pictures taken by the drone
+func (a *Driver) SetPictureFormat(format byte) (byte, error) {
+	return a.adaptor().drone.SetPictureFormat(format)
+}
+
+// SetAutoWhiteBalance sets the white balance mode
+func (a *Driver) SetAutoWhiteBalance(mode byte) (byte, error) {
+	return a.adaptor().drone.SetAutoWhiteBalance(mode)
+}
+
+// SetExposition sets the exposition compensation
+func (a *Driver) SetExposition(value float32) (client.FloatSetting, error) {
+	return a.adaptor().drone.SetExposition(value)
+}
+
+// SetSaturation sets the saturation
+func (a *Driver) SetSaturation(value float32) (client.FloatSetting, error) {
+	return a.adaptor().drone.SetSaturation(value)
+}
+
+// SetTimelapse enables or disables taking a picture every interval seconds
+func (a *Driver) SetTimelapse(enabled bool, interval float32) (client.FloatSetting, error) {
+	return a.adaptor().drone.SetTimelapse(enabled, interval)
+}
+
+// SetVideoAutorecord makes the drone start recording when it takes off
+func (a *Driver) SetVideoAutorecord(enabled bool) (bool, error) {
+	return a.adaptor().drone.SetVideoAutorecord(enabled)
+}
+
+// SetVideoStabilization sets the video stabilization mode
+func (a *Driver) SetVideoStabilization(mode byte) (byte, error) {
+	return a.adaptor().drone.SetVideoStabilization(mode)
+}
+
+// SetVideoRecordingMode chooses between best quality and longest recording time
+func (a *Driver) SetVideoRecordingMode(mode byte) (byte, error) {
+	return a.adaptor().drone.SetVideoRecordingMode(mode)
+}
+
+// SetVideoFramerate sets the video framerate
+func (a *Driver) SetVideoFramerate(framerate byte) (byte, error) {
+	return a.adaptor().drone.SetVideoFramerate(framerate)
+}
+
+// SetVideoResolution sets the recording and streaming resolutions
+func (a *Driver) SetVideoResolution(resolution byte) (byte, error) {
+	return a.adaptor().drone.SetVideoResolution(resolution)
+}
+
+// ElectricFrequency tells the drone the mains frequency powering the lights around it
+func (a *Driver) ElectricFrequency(frequency byte) (byte, error) {
+	return a.adaptor().drone.ElectricFrequency(frequency)
+}
+
+// AntiflickeringMode sets the anti-flickering mode to auto, fixed 50 Hz or fixed 60 Hz
+func (a *Driver) AntiflickeringMode(mode byte) (byte, error) {
+	return a.adaptor().drone.AntiflickeringMode(mode)
+}
+
+// AntiflickeringState returns the anti-flickering configuration last reported by the drone
+func (a *Driver) AntiflickeringState() client.Antiflickering {
+	return a.adaptor().drone.AntiflickeringState()
+}
+
+// GPSSettings returns the GPS and return home settings last reported by the drone
+func (a *Driver) GPSSettings() client.GPSSettings {
+	return a.adaptor().drone.GPSSettings()
+}
+
+// SetHome sets the position the drone returns to when navigating home
+func (a *Driver) SetHome(latitude float64, longitude float64, altitude float64) (client.Location, error) {
+	return a.adaptor().drone.SetHome(latitude, longitude, altitude)
+}
+
+// ResetHome makes the drone use its default home position again
+func (a *Driver) ResetHome() (client.Location, error) {
+	return a.adaptor().drone.ResetHome()
+}
+
+// HomeType sets whether the drone returns to its takeoff position or to the pilot
+func (a *Driver) HomeType(homeType byte) (byte, error) {
+	return a.adaptor().drone.HomeType(homeType)
+}
+
+// ReturnHomeDelay sets how many seconds the drone waits before returning home after losing the connection
+func (a *Driver) ReturnHomeDelay(delay uint16) (uint16, error) {
+	return a.adaptor().drone.ReturnHomeDelay(delay)
+}
+
+// SendControllerGPS sends the position of the pilot to the drone
+func (a *Driver) SendControllerGPS(latitude float64, longitude float64, altitude float64, horizontalAccuracy float64, verticalAccuracy float64) error {
+	return a.adaptor().drone.SendControllerGPS(latitude, longitude, altitude, horizontalAccuracy, verticalAccuracy)
+}
+
+// StartMagnetoCalibration starts the magnetometer calibration
+func (a *Driver) StartMagnetoCalibration() error {
+	return a.adaptor().drone.StartMagnetoCalibration()
+}
+
+// AbortMagnetoCalibration aborts the magnetometer calibration in progress
+func (a *Driver) AbortMagnetoCalibration() error {
+	return a.adaptor().drone.AbortMagnetoCalibration()
+}
+
+// MagnetoCalibrationState returns the calibration progress last reported by the drone
+func (a *Driver) MagnetoCalibrationState() client.MagnetoCalibration {
+	return a.adaptor().drone.MagnetoCalibrationState()
+}
+
+// NextMagnetoCalibrationStep blocks until the calibration progresses from previous
+func (a *Driver) NextMagnetoCalibrationStep(ctx context.Context, previous client.MagnetoCalibration) (client.MagnetoCalibration, error) {
+	return a.adaptor().drone.NextMagnetoCalibrationStep(ctx, previous)
+}
+
+// UploadFlightPlan uploads a MAVLink waypoint file in the QGroundControl plain text format to the drone
+func (a *Driver) UploadFlightPlan(ctx context.Context, plan io.Reader) error {
+	return a.adaptor().drone.UploadFlightPlan(ctx, plan)
+}
+
+// StartFlightPlan plays the uploaded flight plan, or resumes it when paused
+func (a *Driver) StartFlightPlan() error {
+	return a.adaptor().drone.StartFlightPlan()
+}
+
+// PauseFlightPlan pauses the flight plan being played
+func (a *Driver) PauseFlightPlan() error {
+	return a.adaptor().drone.PauseFlightPlan()
+}
+
+// StopFlightPlan stops the flight plan being played
+func (a *Driver) StopFlightPlan() error {
+	return a.adaptor().drone.StopFlightPlan()
+}
+
+// FlightPlanState returns the flight plan state last reported by the drone
+func (a *Driver) FlightPlanState() client.FlightPlan {
+	return a.adaptor().drone.FlightPlanState()
+}
+
+// UploadMission validates m against the piloting settings of the drone and uploads it as the flight plan
+func (a *Driver) UploadMission(ctx context.Context, m *client.Mission) error {
+	return a.adaptor().drone.UploadMission(ctx, m)
+}
+
+// Storage returns the mass storage state last reported by the drone
+func (a *Driver) Storage() client.Storage {
+	return a.adaptor().drone.Storage()
+}
+
+// Product returns the name, serial number and versions of the drone
+func (a *Driver) Product() client.Product {
+	return a.adaptor().drone.Product()
+}
+
+// AllSettings asks the drone for all its settings and blocks until it has sent all of them
+func (a *Driver) AllSettings(ctx context.Context) (client.Settings, error) {
+	return a.adaptor().drone.AllSettings(ctx)
+}
+
+// SettingsReset restores the factory settings of the drone
+func (a *Driver) SettingsReset() error {
+	return a.adaptor().drone.SettingsReset()
+}
+
+// SetProductName renames the drone
+func (a *Driver) SetProductName(name string) (string, error) {
+	return a.adaptor().drone.SetProductName(name)
+}
+
+// SetCountry sets the ISO 3166 code of the country the drone is in
+func (a *Driver) SetCountry(code string) (string, error) {
+	return a.adaptor().drone.SetCountry(code)
+}
+
+// AutoCountry makes the drone pick its country automatically
+func (a *Driver) AutoCountry(automatic bool) (bool, error) {
+	return a.adaptor().drone.AutoCountry(automatic)
+}
+
+// WifiOutdoor makes the Wi-Fi use the channels authorized outdoor
+func (a *Driver) WifiOutdoor(outdoor bool) (bool, error) {
+	return a.adaptor().drone.WifiOutdoor(outdoor)
+}
+
+// FlyingState returns the flying state last reported by the drone
+func (a *Driver) FlyingState() byte {
+	return a.adaptor().drone.FlyingState()
+}
+
+// Overheat returns the overheat state last reported by the drone
+func (a *Driver) Overheat() client.Overheat {
+	return a.adaptor().drone.Overheat()
+}
+
+// SwitchOff asks the drone to cool down by switching off
+func (a *Driver) SwitchOff() error {
+	return a.adaptor().drone.SwitchOff()
+}
+
+// Ventilate asks the drone to cool down with its fans
+func (a *Driver) Ventilate() error {
+	return a.adaptor().drone.Ventilate()
+}
+
+// IsPiloting tells the drone whether the user is actively piloting it
+func (a *Driver) IsPiloting(piloting bool) error {
+	return a.adaptor().drone.IsPiloting(piloting)
+}
+
+// Reboot restarts the drone and blocks until it is connected again, it is
+// refused while the drone is not landed
+func (a *Driver) Reboot(ctx context.Context) error {
+	return a.adaptor().drone.Reboot(ctx)
+}
+
+// State returns the telemetry last reported by the drone
+func (a *Driver) State() client.State {
+	return a.adaptor().drone.State()
+}
+
+// SubscribeState returns a channel receiving the state every time it
+// changes, and a function to unsubscribe
+func (a *Driver) SubscribeState() (<-chan client.StateChange, func()) {
+	return a.adaptor().drone.Subscribe()
+}
+
+// SetAlertPolicy sets the action taken when the drone raises alert
+func (a *Driver) SetAlertPolicy(alert byte, action client.AlertAction) {
+	a.adaptor().drone.SetAlertPolicy(alert, action)
+}
+
+// NavigateHome makes the drone fly back to its home position when start is
+// true, or stops it from doing so
+func (a *Driver) NavigateHome(start bool) error {
+	return a.adaptor().drone.NavigateHome(start)
+}
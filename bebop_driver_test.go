@@ -3,12 +3,15 @@ package bebop
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/platforms/parrot/bebop/client"
 )
 
 var _ gobot.Driver = (*Driver)(nil)
+var _ gobot.Eventer = (*Driver)(nil)
 
 func TestBebopDriverName(t *testing.T) {
 	a := initTestBebopAdaptor()
@@ -17,3 +20,146 @@ func TestBebopDriverName(t *testing.T) {
 	d.SetName("NewName")
 	gobottest.Assert(t, d.Name(), "NewName")
 }
+
+func TestBebopDriverLand(t *testing.T) {
+	a := NewAdaptor()
+	a.drone = &stubDrone{land: func() error { return client.ErrNoAck }}
+	d := NewDriver(a)
+	gobottest.Assert(t, d.Land(), client.ErrNoAck)
+}
+
+func TestBebopDriverCameraOrientation(t *testing.T) {
+	var camera client.CameraState
+	a := NewAdaptor()
+	a.drone = &stubDrone{
+		cameraOrientationV2: func(tilt float32, pan float32) error {
+			camera.Tilt, camera.Pan = tilt, pan
+			return nil
+		},
+		cameraState: func() client.CameraState { return camera },
+	}
+	d := NewDriver(a)
+	gobottest.Assert(t, d.CameraOrientationV2(-90, 45), nil)
+	gobottest.Assert(t, d.CameraState(), client.CameraState{Tilt: -90, Pan: 45})
+}
+
+func TestBebopDriverSetMaxAltitude(t *testing.T) {
+	var altitude float32
+	a := NewAdaptor()
+	a.drone = &stubDrone{
+		// the drone bounds the altitude
+		setMaxAltitude: func(requested float32) (client.FloatSetting, error) {
+			altitude = requested
+			return client.FloatSetting{Current: 150, Min: 0.5, Max: 150}, nil
+		},
+	}
+	d := NewDriver(a)
+	setting, err := d.SetMaxAltitude(200)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, altitude, float32(200))
+	gobottest.Assert(t, setting, client.FloatSetting{Current: 150, Min: 0.5, Max: 150})
+}
+
+func TestBebopDriverStateEvents(t *testing.T) {
+	changes := make(chan client.StateChange)
+	a := NewAdaptor()
+	a.drone = &stubDrone{
+		subscribe: func() (<-chan client.StateChange, func()) {
+			return changes, func() { close(changes) }
+		},
+	}
+	d := NewDriver(a)
+	events := d.Subscribe()
+	gobottest.Assert(t, d.Start(), nil)
+
+	changes <- client.StateChange{
+		State: client.State{
+			FlyingState: client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_HOVERING,
+			Battery:     80,
+			Connected:   true,
+		},
+		Changed: client.StateFlyingState | client.StateBattery | client.StateConnected,
+	}
+	// the alert is cleared
+	changes <- client.StateChange{
+		State:   client.State{Alert: client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_NONE},
+		Changed: client.StateAlert,
+	}
+
+	for _, want := range []gobot.Event{
+		{Name: Hovering, Data: client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_HOVERING},
+		{Name: Battery, Data: uint8(80)},
+		{Name: Connected},
+		{Name: Alert, Data: client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_NONE},
+	} {
+		event := <-events
+		gobottest.Assert(t, *event, want)
+	}
+
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestBebopDriverHalt(t *testing.T) {
+	storageFull := make(chan client.Storage, 1)
+	a := NewAdaptor()
+	a.drone = &stubDrone{
+		storageFull: func() <-chan client.Storage { return storageFull },
+	}
+	d := NewDriver(a)
+	events := d.Subscribe()
+
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.Start(), nil)
+
+	storageFull <- client.Storage{}
+	gobottest.Assert(t, (<-events).Name, StorageFull)
+	gobottest.Assert(t, d.Halt(), nil)
+
+	// nothing consumes the notifications once halted
+	storageFull <- client.Storage{}
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event %v", event.Name)
+	case <-time.After(50 * time.Millisecond):
+	}
+	gobottest.Assert(t, len(storageFull), 1)
+}
+
+func TestBebopDriverStartTwice(t *testing.T) {
+	subscribed := 0
+	a := NewAdaptor()
+	a.drone = &stubDrone{
+		subscribe: func() (<-chan client.StateChange, func()) {
+			subscribed++
+			return nil, func() { subscribed-- }
+		},
+	}
+	d := NewDriver(a)
+
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, subscribed, 1)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, subscribed, 0)
+}
+
+func TestBebopDriverAlertHandled(t *testing.T) {
+	alerts := make(chan client.Alert)
+	a := NewAdaptor()
+	a.drone = &stubDrone{
+		alerts: func() (<-chan client.Alert, func()) { return alerts, func() {} },
+	}
+	d := NewDriver(a)
+	events := d.Subscribe()
+	gobottest.Assert(t, d.Start(), nil)
+
+	alert := client.Alert{
+		State:  client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CRITICALBATTERY,
+		Action: client.AlertActionLand,
+	}
+	alerts <- alert
+	gobottest.Assert(t, *<-events, gobot.Event{Name: AlertHandled, Data: alert})
+
+	gobottest.Assert(t, d.Halt(), nil)
+}
@@ -0,0 +1,128 @@
+package client
+
+// alertBuffer is how many alerts a subscriber of Alerts may lag behind
+// before alerts are dropped.
+const alertBuffer = 8
+
+// AlertAction is what the client does on its own when the drone raises an
+// alert.
+type AlertAction byte
+
+const (
+	// AlertActionNone does nothing
+	AlertActionNone AlertAction = iota
+	// AlertActionStop stops all movements by zeroing the Pcmd
+	AlertActionStop
+	// AlertActionLand lands the drone
+	AlertActionLand
+	// AlertActionNavigateHome makes the drone fly back home, or land when it
+	// has no GPS fix
+	AlertActionNavigateHome
+)
+
+// Alert is sent by the drone when an alert is raised, along with the action
+// taken by the client.
+type Alert struct {
+	// State is one of ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_*
+	State  byte
+	Action AlertAction
+	// Err is the error of the action
+	Err error
+}
+
+// defaultAlertPolicies stop the drone when a motor is cut out and land it
+// when its battery is critical.
+func defaultAlertPolicies() map[byte]AlertAction {
+	return map[byte]AlertAction{
+		ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CUT_OUT:         AlertActionStop,
+		ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CRITICALBATTERY: AlertActionLand,
+	}
+}
+
+// SetAlertPolicy sets the action taken when the drone raises alert, one of
+// ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_*. By default a
+// cut-out stops all movements and a critical battery lands the drone.
+func (b *Bebop) SetAlertPolicy(alert byte, action AlertAction) {
+	b.stateLock.Lock()
+	defer b.stateLock.Unlock()
+
+	b.alertPolicies[alert] = action
+}
+
+// Alerts returns a channel receiving the alerts raised by the drone once
+// their action ran, and a function to unsubscribe which closes the channel.
+// Alerts are dropped when the subscriber lags behind by more than
+// alertBuffer of them.
+func (b *Bebop) Alerts() (<-chan Alert, func()) {
+	c := make(chan Alert, alertBuffer)
+
+	b.stateLock.Lock()
+	b.alertSubscribers[c] = struct{}{}
+	b.stateLock.Unlock()
+
+	return c, func() {
+		b.stateLock.Lock()
+		defer b.stateLock.Unlock()
+
+		if _, ok := b.alertSubscribers[c]; ok {
+			delete(b.alertSubscribers, c)
+			close(c)
+		}
+	}
+}
+
+// NavigateHome makes the drone fly back to its home position when start is
+// true, or stops it from doing so.
+func (b *Bebop) NavigateHome(start bool) error {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PilotingNavigateHome
+	//
+	// uint8 - start 1 to start the navigate home, 0 to stop it
+	//
+
+	return b.writeWithAck(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTING,
+		ARCOMMANDS_ID_ARDRONE3_PILOTING_CMD_NAVIGATEHOME,
+		start,
+	))
+}
+
+// raiseAlert runs the action of the policy of alert and notifies the
+// subscribers of Alerts. It must be called with the stateLock held.
+func (b *Bebop) raiseAlert(alert byte) {
+	if alert == ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_NONE {
+		return
+	}
+
+	action := b.alertPolicies[alert]
+	fixed := b.state.GPSFixed
+
+	// the receiver must not block on the writer
+	go func() {
+		a := Alert{State: alert, Action: action}
+
+		switch action {
+		case AlertActionStop:
+			a.Err = b.Stop()
+		case AlertActionLand:
+			a.Err = b.Land()
+		case AlertActionNavigateHome:
+			if fixed {
+				a.Err = b.NavigateHome(true)
+			} else {
+				a.Err = b.Land()
+			}
+		}
+
+		b.stateLock.RLock()
+		defer b.stateLock.RUnlock()
+
+		for c := range b.alertSubscribers {
+			select {
+			case c <- a:
+			default:
+			}
+		}
+	}()
+}
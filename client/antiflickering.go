@@ -0,0 +1,180 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Antiflickering is the anti-flickering configuration reported by the drone.
+type Antiflickering struct {
+	// ElectricFrequency is one of ARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE_*
+	ElectricFrequency byte
+	// Mode is one of ARCOMMANDS_ARDRONE3_ANTIFLICKERING_SETMODE_VALUE_*
+	Mode byte
+}
+
+// sixtyHertzCountries use 60 Hz mains electricity, the rest of the world
+// uses 50 Hz apart from mixedHertzCountries.
+var sixtyHertzCountries = map[string]bool{
+	"AG": true, "AS": true, "AW": true, "BM": true, "BR": true, "BS": true,
+	"BZ": true, "CA": true, "CO": true, "CR": true, "CU": true, "DO": true,
+	"EC": true, "GT": true, "GU": true, "HN": true, "HT": true, "KN": true,
+	"KR": true, "KY": true, "LR": true, "MP": true, "MS": true, "MX": true,
+	"NI": true, "PA": true, "PE": true, "PH": true, "PR": true, "PW": true,
+	"SA": true, "SR": true, "SV": true, "TT": true, "TW": true, "US": true,
+	"VE": true, "VG": true, "VI": true,
+}
+
+// mixedHertzCountries use both 50 Hz and 60 Hz depending on the region.
+var mixedHertzCountries = map[string]bool{
+	"JP": true,
+}
+
+// ElectricFrequencyForLocale returns the mains frequency of the region of
+// locale, such as "en_US.UTF-8" or "zh-Hant-TW". ok is false for locales
+// without a region, such as "en" or "C", and for countries using both
+// frequencies.
+func ElectricFrequencyForLocale(locale string) (frequency byte, ok bool, err error) {
+	tag := locale
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	// the first subtag is the language, the region is the two letters
+	// subtag following it and an optional script
+	var country string
+	subtags := strings.FieldsFunc(tag, func(r rune) bool { return r == '_' || r == '-' })
+	for i := 1; i < len(subtags) && country == ""; i++ {
+		if len(subtags[i]) == 2 {
+			country = strings.ToUpper(subtags[i])
+		}
+	}
+
+	if country == "" {
+		return 0, false, nil
+	}
+	if country[0] < 'A' || country[0] > 'Z' || country[1] < 'A' || country[1] > 'Z' {
+		return 0, false, fmt.Errorf("invalid region in locale %q", locale)
+	}
+
+	switch {
+	case mixedHertzCountries[country]:
+		return 0, false, nil
+	case sixtyHertzCountries[country]:
+		return ARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE_SIXTYHERTZ, true, nil
+	default:
+		return ARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE_FIFTYHERTZ, true, nil
+	}
+}
+
+// LocaleAntiflickering tells the drone the mains frequency of the country of
+// locale and lets it pick the anti-flickering mode automatically.
+func (b *Bebop) LocaleAntiflickering(locale string) error {
+	frequency, ok, err := ElectricFrequencyForLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		if _, err := b.ElectricFrequency(frequency); err != nil {
+			return err
+		}
+	}
+
+	_, err = b.AntiflickeringMode(ARCOMMANDS_ARDRONE3_ANTIFLICKERING_SETMODE_VALUE_AUTO)
+	return err
+}
+
+// ElectricFrequency tells the drone the frequency of the mains electricity
+// powering the lights around it and returns the value confirmed by the
+// drone.
+func (b *Bebop) ElectricFrequency(frequency byte) (byte, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3AntiflickeringElectricFrequency
+	//
+	// eARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE - value Type of the electric frequency
+	//
+
+	if err := checkEnum("electric frequency", frequency, ARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE_MAX); err != nil {
+		return 0, err
+	}
+
+	err := b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_ANTIFLICKERING,
+		ARCOMMANDS_ID_ARDRONE3_ANTIFLICKERING_CMD_ELECTRICFREQUENCY,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_ANTIFLICKERINGSTATE,
+		ARCOMMANDS_ID_ARDRONE3_ANTIFLICKERINGSTATE_CMD_ELECTRICFREQUENCYCHANGED,
+		uint32(frequency),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return b.AntiflickeringState().ElectricFrequency, nil
+}
+
+// AntiflickeringMode sets the anti-flickering mode and returns the mode
+// confirmed by the drone.
+func (b *Bebop) AntiflickeringMode(mode byte) (byte, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3AntiflickeringSetMode
+	//
+	// eARCOMMANDS_ARDRONE3_ANTIFLICKERING_SETMODE_VALUE - value Mode of the anti flickering functionnality
+	//
+
+	if err := checkEnum("anti-flickering mode", mode, ARCOMMANDS_ARDRONE3_ANTIFLICKERING_SETMODE_VALUE_MAX); err != nil {
+		return 0, err
+	}
+
+	err := b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_ANTIFLICKERING,
+		ARCOMMANDS_ID_ARDRONE3_ANTIFLICKERING_CMD_SETMODE,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_ANTIFLICKERINGSTATE,
+		ARCOMMANDS_ID_ARDRONE3_ANTIFLICKERINGSTATE_CMD_MODECHANGED,
+		uint32(mode),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return b.AntiflickeringState().Mode, nil
+}
+
+// AntiflickeringState returns the anti-flickering configuration last
+// reported by the drone.
+func (b *Bebop) AntiflickeringState() Antiflickering {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.antiflickering
+}
+
+func (b *Bebop) decodeAntiflickeringState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_ARDrone3AntiflickeringState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_ARDRONE3_ANTIFLICKERINGSTATE_CMD_ELECTRICFREQUENCYCHANGED,
+		ARCOMMANDS_ID_ARDRONE3_ANTIFLICKERINGSTATE_CMD_MODECHANGED:
+		//
+		// eARCOMMANDS_ARDRONE3_ANTIFLICKERINGSTATE_* - value
+		//
+		var value uint32
+		if err := decodeArgs(args, &value); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		if cmd == ARCOMMANDS_ID_ARDRONE3_ANTIFLICKERINGSTATE_CMD_ELECTRICFREQUENCYCHANGED {
+			b.antiflickering.ElectricFrequency = byte(value)
+		} else {
+			b.antiflickering.Mode = byte(value)
+		}
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"errors"
+)
+
+// MagnetoCalibration is the progress of the magnetometer calibration
+// reported by the drone.
+type MagnetoCalibration struct {
+	// Required is true when the drone needs to be calibrated before flying
+	Required bool
+	// Started is true while a calibration is in progress
+	Started bool
+	// Axis the drone must currently be rotated around, one of
+	// ARCOMMANDS_COMMON_CALIBRATIONSTATE_MAGNETOCALIBRATIONAXISTOCALIBRATECHANGED_AXIS_*
+	Axis byte
+	// XAxis, YAxis and ZAxis are true once the axis is calibrated
+	XAxis bool
+	YAxis bool
+	ZAxis bool
+	// Failed is true if the calibration failed
+	Failed bool
+}
+
+// Done returns true once every axis has been calibrated.
+func (m MagnetoCalibration) Done() bool {
+	return m.XAxis && m.YAxis && m.ZAxis
+}
+
+// ErrMagnetoCalibrationFailed is returned when the drone reports that the
+// magnetometer calibration failed.
+var ErrMagnetoCalibrationFailed = errors.New("magnetometer calibration failed")
+
+// ErrMagnetoCalibrationStopped is returned when the calibration stops before
+// every axis has been calibrated, for instance after it was aborted.
+var ErrMagnetoCalibrationStopped = errors.New("magnetometer calibration stopped")
+
+// StartMagnetoCalibration starts the magnetometer calibration, follow the
+// steps by passing MagnetoCalibrationState to NextMagnetoCalibrationStep.
+func (b *Bebop) StartMagnetoCalibration() error {
+	return b.magnetoCalibration(true)
+}
+
+// AbortMagnetoCalibration aborts the magnetometer calibration in progress.
+func (b *Bebop) AbortMagnetoCalibration() error {
+	return b.magnetoCalibration(false)
+}
+
+func (b *Bebop) magnetoCalibration(calibrate bool) error {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonCalibrationMagnetoCalibration
+	//
+	// uint8 - calibrate 1 if the calibration should be started, 0 if it should be aborted
+	//
+
+	return b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_CALIBRATION,
+		ARCOMMANDS_ID_COMMON_CALIBRATION_CMD_MAGNETOCALIBRATION,
+		ARCOMMANDS_ID_COMMON_CLASS_CALIBRATIONSTATE,
+		ARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD_MAGNETOCALIBRATIONSTARTEDCHANGED,
+		calibrate,
+	)
+}
+
+// MagnetoCalibrationState returns the calibration progress last reported by
+// the drone.
+func (b *Bebop) MagnetoCalibrationState() MagnetoCalibration {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.calibration
+}
+
+// NextMagnetoCalibrationStep blocks until the calibration progresses from
+// previous, the step last returned or MagnetoCalibrationState, that is the
+// drone asks for another axis or reports an axis as calibrated, and returns
+// the new progress. It returns ErrMagnetoCalibrationFailed or
+// ErrMagnetoCalibrationStopped when the calibration ends unsuccessfully.
+func (b *Bebop) NextMagnetoCalibrationStep(ctx context.Context, previous MagnetoCalibration) (MagnetoCalibration, error) {
+	err := b.waitFor(ctx, func() bool {
+		c := b.calibration
+		return c.Failed || !c.Started ||
+			c.Axis != previous.Axis ||
+			c.XAxis != previous.XAxis || c.YAxis != previous.YAxis || c.ZAxis != previous.ZAxis
+	})
+	if err != nil {
+		return previous, err
+	}
+
+	c := b.MagnetoCalibrationState()
+	switch {
+	case c.Failed:
+		return c, ErrMagnetoCalibrationFailed
+	case !c.Started && !c.Done():
+		return c, ErrMagnetoCalibrationStopped
+	}
+
+	return c, nil
+}
+
+func (b *Bebop) decodeCalibrationState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_CommonCalibrationState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD_MAGNETOCALIBRATIONSTATECHANGED:
+		//
+		// uint8 - xAxisCalibration State of the x axis (roll) calibration : 1 if calibration is done, 0 otherwise
+		// uint8 - yAxisCalibration State of the y axis (pitch) calibration : 1 if calibration is done, 0 otherwise
+		// uint8 - zAxisCalibration State of the z axis (yaw) calibration : 1 if calibration is done, 0 otherwise
+		// uint8 - calibrationFailed 1 if calibration has failed, 0 otherwise
+		//
+		var x, y, z, failed uint8
+		if err := decodeArgs(args, &x, &y, &z, &failed); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.calibration.XAxis = x == 1
+		b.calibration.YAxis = y == 1
+		b.calibration.ZAxis = z == 1
+		b.calibration.Failed = failed == 1
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD_MAGNETOCALIBRATIONREQUIREDSTATE,
+		ARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD_MAGNETOCALIBRATIONSTARTEDCHANGED:
+		//
+		// uint8 - 1 if required/started, 0 otherwise
+		//
+		var on uint8
+		if err := decodeArgs(args, &on); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		if cmd == ARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD_MAGNETOCALIBRATIONREQUIREDSTATE {
+			b.calibration.Required = on == 1
+		} else {
+			b.calibration.Started = on == 1
+		}
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD_MAGNETOCALIBRATIONAXISTOCALIBRATECHANGED:
+		//
+		// eARCOMMANDS_COMMON_CALIBRATIONSTATE_MAGNETOCALIBRATIONAXISTOCALIBRATECHANGED_AXIS - axis The axis to calibrate
+		//
+		var axis uint32
+		if err := decodeArgs(args, &axis); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.calibration.Axis = byte(axis)
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package client
+
+// CameraState is the orientation of the camera as reported by the drone,
+// angles are in degrees and speeds in degrees per second.
+type CameraState struct {
+	Tilt         float32
+	Pan          float32
+	DefaultTilt  float32
+	DefaultPan   float32
+	MaxTiltSpeed float32
+	MaxPanSpeed  float32
+}
+
+// CameraOrientation points the camera, tilt and pan are in degrees.
+// Deprecated by the drone in favor of CameraOrientationV2.
+func (b *Bebop) CameraOrientation(tilt int8, pan int8) error {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3CameraOrientation
+	//
+	// int8 - tilt Tilt camera consign for the drone (in degree)
+	// int8 - pan Pan camera consign for the drone (in degree)
+	//
+
+	cmd := generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_CAMERA,
+		ARCOMMANDS_ID_ARDRONE3_CAMERA_CMD_ORIENTATION,
+		tilt,
+		pan,
+	)
+
+	_, err := b.write(b.networkFrameGenerator(cmd, ARNETWORKAL_FRAME_TYPE_DATA, BD_NET_CD_NONACK_ID).Bytes())
+	return err
+}
+
+// CameraOrientationV2 points the camera, tilt and pan are in degrees and
+// limited by the drone to its own range.
+func (b *Bebop) CameraOrientationV2(tilt float32, pan float32) error {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3CameraOrientationV2
+	//
+	// float - tilt Tilt camera consign for the drone (in degree)
+	// float - pan Pan camera consign for the drone (in degree)
+	//
+
+	cmd := generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_CAMERA,
+		ARCOMMANDS_ID_ARDRONE3_CAMERA_CMD_ORIENTATIONV2,
+		tilt,
+		pan,
+	)
+
+	_, err := b.write(b.networkFrameGenerator(cmd, ARNETWORKAL_FRAME_TYPE_DATA, BD_NET_CD_NONACK_ID).Bytes())
+	return err
+}
+
+// CameraVelocity moves the camera at the given speed, tilt and pan are in
+// degrees per second and are bounded by MaxTiltSpeed and MaxPanSpeed.
+func (b *Bebop) CameraVelocity(tilt float32, pan float32) error {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3CameraVelocity
+	//
+	// float - tilt Tilt camera velocity consign (in deg/s)
+	// float - pan Pan camera velocity consign (in deg/s)
+	//
+
+	cmd := generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_CAMERA,
+		ARCOMMANDS_ID_ARDRONE3_CAMERA_CMD_VELOCITY,
+		tilt,
+		pan,
+	)
+
+	_, err := b.write(b.networkFrameGenerator(cmd, ARNETWORKAL_FRAME_TYPE_DATA, BD_NET_CD_NONACK_ID).Bytes())
+	return err
+}
+
+// CameraState returns the last camera orientation reported by the drone.
+func (b *Bebop) CameraState() CameraState {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.camera
+}
+
+func (b *Bebop) decodeCameraState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_ARDrone3CameraState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_ORIENTATION,
+		ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_DEFAULTCAMERAORIENTATION:
+		var tilt, pan int8
+		if err := decodeArgs(args, &tilt, &pan); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		if cmd == ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_ORIENTATION {
+			b.camera.Tilt, b.camera.Pan = float32(tilt), float32(pan)
+		} else {
+			b.camera.DefaultTilt, b.camera.DefaultPan = float32(tilt), float32(pan)
+		}
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_ORIENTATIONV2,
+		ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_DEFAULTCAMERAORIENTATIONV2,
+		ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_VELOCITYRANGE:
+		var tilt, pan float32
+		if err := decodeArgs(args, &tilt, &pan); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		switch cmd {
+		case ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_ORIENTATIONV2:
+			b.camera.Tilt, b.camera.Pan = tilt, pan
+		case ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_DEFAULTCAMERAORIENTATIONV2:
+			b.camera.DefaultTilt, b.camera.DefaultPan = tilt, pan
+		default:
+			b.camera.MaxTiltSpeed, b.camera.MaxPanSpeed = tilt, pan
+		}
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
@@ -2,12 +2,31 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"net"
+	"sync"
 	"time"
 )
 
+const (
+	// ackTimeout is how long to wait for the drone to acknowledge a frame
+	// before it is sent again
+	ackTimeout = 150 * time.Millisecond
+	// ackRetries is how many times an acknowledged frame is sent
+	ackRetries = 5
+	// commandTimeout is how long to wait for the drone to answer a command
+	commandTimeout = 2 * time.Second
+	// connectionTimeout is how long the drone may send nothing before it is
+	// considered disconnected
+	connectionTimeout = 2 * time.Second
+)
+
+// ErrNoAck is returned when the drone never acknowledged a command.
+var ErrNoAck = errors.New("command was not acknowledged by the drone")
+
 func validatePitch(val int) int {
 	if val > 100 {
 		return 100
@@ -116,10 +135,14 @@ func networkFrameGenerator() func(*bytes.Buffer, byte, byte) *bytes.Buffer {
 
 	// each frame id has it's own sequence number
 	seq := make(map[byte]byte)
+	lock := sync.Mutex{}
 
 	hlen := 7 // size of ARNETWORKAL_Frame_t header
 
 	return func(cmd *bytes.Buffer, frameType byte, id byte) *bytes.Buffer {
+		lock.Lock()
+		defer lock.Unlock()
+
 		if _, ok := seq[id]; !ok {
 			seq[id] = 0
 		}
@@ -156,7 +179,7 @@ type Pcmd struct {
 
 type Bebop struct {
 	IP                    string
-	NavData               map[string]string
+	Locale                string
 	Pcmd                  Pcmd
 	tmpFrame              tmpFrame
 	C2dPort               int
@@ -164,23 +187,70 @@ type Bebop struct {
 	RTPStreamPort         int
 	RTPControlPort        int
 	DiscoveryPort         int
+	FlightPlanPort        int
+	StopVideoOnOverheat   bool
+	ReportPiloting        bool
+	PilotingTimeout       time.Duration
 	c2dClient             *net.UDPConn
 	d2cClient             *net.UDPConn
 	discoveryClient       *net.TCPConn
 	networkFrameGenerator func(*bytes.Buffer, byte, byte) *bytes.Buffer
 	video                 chan []byte
 	writeChan             chan []byte
+	ackLock               sync.Mutex
+	acks                  map[byte]chan struct{}
+	stateLock             sync.RWMutex
+	pcmdLock              sync.Mutex
+	stateChanged          chan struct{}
+	camera                CameraState
+	picture               PictureState
+	pictureEvent          PictureEvent
+	pictureEvents         int
+	videoState            VideoState
+	videoEvent            VideoEvent
+	videoEvents           int
+	received              map[uint32]int
+	pilotingSettings      PilotingSettings
+	speedSettings         SpeedSettings
+	speedProfileLock      sync.Mutex
+	wifiScan              []WifiNetwork
+	wifiChannels          []WifiChannel
+	wifiSelection         WifiSelection
+	pictureSettings       PictureSettings
+	antiflickering        Antiflickering
+	gpsSettings           GPSSettings
+	calibration           MagnetoCalibration
+	flightPlan            FlightPlan
+	storage               Storage
+	storageFull           chan Storage
+	product               Product
+	country               string
+	autoCountry           bool
+	wifiOutdoor           bool
+	overheat              Overheat
+	overheated            chan Overheat
+	piloting              bool
+	pilotingActive        time.Time
+	pilotingReported      bool
+	pilotingReporting     bool
+	state                 State
+	subscribers           map[chan StateChange]struct{}
+	sampleSubscribers     map[chan State]struct{}
+	lastFrame             time.Time
+	alertPolicies         map[byte]AlertAction
+	alertSubscribers      map[chan Alert]struct{}
 }
 
 func New() *Bebop {
 	return &Bebop{
 		IP:                    "192.168.42.1",
-		NavData:               make(map[string]string),
 		C2dPort:               54321,
 		D2cPort:               43210,
 		RTPStreamPort:         55004,
 		RTPControlPort:        55005,
 		DiscoveryPort:         44444,
+		FlightPlanPort:        61,
+		PilotingTimeout:       5 * time.Second,
 		networkFrameGenerator: networkFrameGenerator(),
 		Pcmd: Pcmd{
 			Flag:  0,
@@ -190,9 +260,18 @@ func New() *Bebop {
 			Gaz:   0,
 			Psi:   0,
 		},
-		tmpFrame:  tmpFrame{},
-		video:     make(chan []byte),
-		writeChan: make(chan []byte),
+		tmpFrame:          tmpFrame{},
+		video:             make(chan []byte),
+		writeChan:         make(chan []byte),
+		acks:              make(map[byte]chan struct{}),
+		stateChanged:      make(chan struct{}),
+		received:          make(map[uint32]int),
+		storageFull:       make(chan Storage, 1),
+		overheated:        make(chan Overheat, 1),
+		subscribers:       make(map[chan StateChange]struct{}),
+		sampleSubscribers: make(map[chan State]struct{}),
+		alertPolicies:     defaultAlertPolicies(),
+		alertSubscribers:  make(map[chan Alert]struct{}),
 	}
 }
 
@@ -201,17 +280,66 @@ func (b *Bebop) write(buf []byte) (int, error) {
 	return 0, nil
 }
 
+// writeWithAck sends cmd on the acknowledged buffer, the frame is sent
+// again until the drone acknowledges it or ackRetries is exceeded.
+func (b *Bebop) writeWithAck(cmd *bytes.Buffer) error {
+	//
+	// libARNetwork/Sources/ARNETWORK_Sender.c#ARNETWORK_Sender_ThreadRun
+	//
+
+	frame := b.networkFrameGenerator(cmd, ARNETWORKAL_FRAME_TYPE_DATA_WITH_ACK, BD_NET_CD_ACK_ID).Bytes()
+	seq := frame[2]
+
+	ack := make(chan struct{})
+
+	b.ackLock.Lock()
+	b.acks[seq] = ack
+	b.ackLock.Unlock()
+
+	defer func() {
+		b.ackLock.Lock()
+		delete(b.acks, seq)
+		b.ackLock.Unlock()
+	}()
+
+	for i := 0; i < ackRetries; i++ {
+		if _, err := b.write(frame); err != nil {
+			return err
+		}
+
+		select {
+		case <-ack:
+			return nil
+		case <-time.After(ackTimeout):
+		}
+	}
+
+	return ErrNoAck
+}
+
 func (b *Bebop) Discover() error {
-	addr, err := net.ResolveTCPAddr("tcp", fmt.Sprintf("%s:%d", b.IP, b.DiscoveryPort))
+	return b.discover(context.Background())
+}
+
+// dialDiscovery connects to the discovery service of the drone.
+func (b *Bebop) dialDiscovery(ctx context.Context) (net.Conn, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", b.IP, b.DiscoveryPort))
+}
+
+// discover tells the drone which ports to use, it is aborted when ctx is
+// done.
+func (b *Bebop) discover(ctx context.Context) error {
+	conn, err := b.dialDiscovery(ctx)
 
 	if err != nil {
 		return err
 	}
 
-	b.discoveryClient, err = net.DialTCP("tcp", nil, addr)
+	b.discoveryClient = conn.(*net.TCPConn)
 
-	if err != nil {
-		return err
+	if deadline, ok := ctx.Deadline(); ok {
+		b.discoveryClient.SetDeadline(deadline)
 	}
 
 	b.discoveryClient.Write(
@@ -234,6 +362,7 @@ func (b *Bebop) Discover() error {
 	_, err = b.discoveryClient.Read(data)
 
 	if err != nil {
+		b.discoveryClient.Close()
 		return err
 	}
 
@@ -300,6 +429,8 @@ func (b *Bebop) Connect() error {
 			if err != nil {
 				fmt.Println("pcmd c2dClient.Write", err)
 			}
+			b.checkPiloting(time.Now())
+			b.checkConnection(time.Now())
 			time.Sleep(25 * time.Millisecond)
 		}
 	}()
@@ -307,10 +438,19 @@ func (b *Bebop) Connect() error {
 	if err := b.GenerateAllStates(); err != nil {
 		return err
 	}
+	if err := b.GenerateAllSettings(); err != nil {
+		return err
+	}
 	if err := b.FlatTrim(); err != nil {
 		return err
 	}
 
+	if b.Locale != "" {
+		if err := b.LocaleAntiflickering(b.Locale); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -352,6 +492,21 @@ func (b *Bebop) GenerateAllStates() error {
 	return err
 }
 
+func (b *Bebop) GenerateAllSettings() error {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonSettingsAllSettings
+	//
+
+	cmd := generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_SETTINGS,
+		ARCOMMANDS_ID_COMMON_SETTINGS_CMD_ALLSETTINGS,
+	)
+
+	_, err := b.write(b.networkFrameGenerator(cmd, ARNETWORKAL_FRAME_TYPE_DATA, BD_NET_CD_NONACK_ID).Bytes())
+	return err
+}
+
 func (b *Bebop) TakeOff() error {
 	//
 	//  ARCOMMANDS_Generator_GenerateARDrone3PilotingTakeOff
@@ -368,7 +523,12 @@ func (b *Bebop) TakeOff() error {
 	cmd.Write(tmp.Bytes())
 
 	_, err := b.write(b.networkFrameGenerator(cmd, ARNETWORKAL_FRAME_TYPE_DATA, BD_NET_CD_NONACK_ID).Bytes())
-	return err
+	if err != nil {
+		return err
+	}
+
+	b.setPiloting(true, time.Now())
+	return nil
 }
 
 func (b *Bebop) Land() error {
@@ -387,58 +547,90 @@ func (b *Bebop) Land() error {
 	cmd.Write(tmp.Bytes())
 
 	_, err := b.write(b.networkFrameGenerator(cmd, ARNETWORKAL_FRAME_TYPE_DATA, BD_NET_CD_NONACK_ID).Bytes())
-	return err
+	if err != nil {
+		return err
+	}
+
+	b.setPiloting(false, time.Now())
+	return nil
 }
 
 func (b *Bebop) Up(val int) error {
+	b.pcmdLock.Lock()
+	defer b.pcmdLock.Unlock()
+
 	b.Pcmd.Flag = 1
 	b.Pcmd.Gaz = validatePitch(val)
 	return nil
 }
 
 func (b *Bebop) Down(val int) error {
+	b.pcmdLock.Lock()
+	defer b.pcmdLock.Unlock()
+
 	b.Pcmd.Flag = 1
 	b.Pcmd.Gaz = validatePitch(val) * -1
 	return nil
 }
 
 func (b *Bebop) Forward(val int) error {
+	b.pcmdLock.Lock()
+	defer b.pcmdLock.Unlock()
+
 	b.Pcmd.Flag = 1
 	b.Pcmd.Pitch = validatePitch(val)
 	return nil
 }
 
 func (b *Bebop) Backward(val int) error {
+	b.pcmdLock.Lock()
+	defer b.pcmdLock.Unlock()
+
 	b.Pcmd.Flag = 1
 	b.Pcmd.Pitch = validatePitch(val) * -1
 	return nil
 }
 
 func (b *Bebop) Right(val int) error {
+	b.pcmdLock.Lock()
+	defer b.pcmdLock.Unlock()
+
 	b.Pcmd.Flag = 1
 	b.Pcmd.Roll = validatePitch(val)
 	return nil
 }
 
 func (b *Bebop) Left(val int) error {
+	b.pcmdLock.Lock()
+	defer b.pcmdLock.Unlock()
+
 	b.Pcmd.Flag = 1
 	b.Pcmd.Roll = validatePitch(val) * -1
 	return nil
 }
 
 func (b *Bebop) Clockwise(val int) error {
+	b.pcmdLock.Lock()
+	defer b.pcmdLock.Unlock()
+
 	b.Pcmd.Flag = 1
 	b.Pcmd.Yaw = validatePitch(val)
 	return nil
 }
 
 func (b *Bebop) CounterClockwise(val int) error {
+	b.pcmdLock.Lock()
+	defer b.pcmdLock.Unlock()
+
 	b.Pcmd.Flag = 1
 	b.Pcmd.Yaw = validatePitch(val) * -1
 	return nil
 }
 
 func (b *Bebop) Stop() error {
+	b.pcmdLock.Lock()
+	defer b.pcmdLock.Unlock()
+
 	b.Pcmd = Pcmd{
 		Flag:  0,
 		Roll:  0,
@@ -451,6 +643,24 @@ func (b *Bebop) Stop() error {
 	return nil
 }
 
+// SetPcmd sets the piloting command sent to the drone at every pcmd, it is
+// safe to call from any goroutine unlike setting the Pcmd field.
+func (b *Bebop) SetPcmd(pcmd Pcmd) error {
+	b.pcmdLock.Lock()
+	defer b.pcmdLock.Unlock()
+
+	b.Pcmd = pcmd
+	return nil
+}
+
+// CurrentPcmd returns the piloting command sent to the drone at every pcmd.
+func (b *Bebop) CurrentPcmd() Pcmd {
+	b.pcmdLock.Lock()
+	defer b.pcmdLock.Unlock()
+
+	return b.Pcmd
+}
+
 func (b *Bebop) generatePcmd() *bytes.Buffer {
 	//
 	// ARCOMMANDS_Generator_GenerateARDrone3PilotingPCMD
@@ -464,6 +674,7 @@ func (b *Bebop) generatePcmd() *bytes.Buffer {
 	//         controlling device (deg) [-180;180]
 	//
 
+	pcmd := b.CurrentPcmd()
 	cmd := &bytes.Buffer{}
 	tmp := &bytes.Buffer{}
 
@@ -475,27 +686,27 @@ func (b *Bebop) generatePcmd() *bytes.Buffer {
 	cmd.Write(tmp.Bytes())
 
 	tmp = &bytes.Buffer{}
-	binary.Write(tmp, binary.LittleEndian, uint8(b.Pcmd.Flag))
+	binary.Write(tmp, binary.LittleEndian, uint8(pcmd.Flag))
 	cmd.Write(tmp.Bytes())
 
 	tmp = &bytes.Buffer{}
-	binary.Write(tmp, binary.LittleEndian, int8(b.Pcmd.Roll))
+	binary.Write(tmp, binary.LittleEndian, int8(pcmd.Roll))
 	cmd.Write(tmp.Bytes())
 
 	tmp = &bytes.Buffer{}
-	binary.Write(tmp, binary.LittleEndian, int8(b.Pcmd.Pitch))
+	binary.Write(tmp, binary.LittleEndian, int8(pcmd.Pitch))
 	cmd.Write(tmp.Bytes())
 
 	tmp = &bytes.Buffer{}
-	binary.Write(tmp, binary.LittleEndian, int8(b.Pcmd.Yaw))
+	binary.Write(tmp, binary.LittleEndian, int8(pcmd.Yaw))
 	cmd.Write(tmp.Bytes())
 
 	tmp = &bytes.Buffer{}
-	binary.Write(tmp, binary.LittleEndian, int8(b.Pcmd.Gaz))
+	binary.Write(tmp, binary.LittleEndian, int8(pcmd.Gaz))
 	cmd.Write(tmp.Bytes())
 
 	tmp = &bytes.Buffer{}
-	binary.Write(tmp, binary.LittleEndian, uint32(b.Pcmd.Psi))
+	binary.Write(tmp, binary.LittleEndian, uint32(pcmd.Psi))
 	cmd.Write(tmp.Bytes())
 
 	return b.networkFrameGenerator(cmd, ARNETWORKAL_FRAME_TYPE_DATA, BD_NET_CD_NONACK_ID)
@@ -525,6 +736,21 @@ func (b *Bebop) createPong(frame NetworkFrame) *bytes.Buffer {
 
 func (b *Bebop) packetReceiver(buf []byte) {
 	frame := NewNetworkFrame(buf)
+	b.frameReceived(time.Now())
+
+	//
+	// libARNetwork/Sources/ARNETWORK_Receiver.c#ARNETWORK_Receiver_ThreadRun
+	//
+	if frame.Type == int(ARNETWORKAL_FRAME_TYPE_ACK) &&
+		frame.Id == int(uint16(BD_NET_CD_ACK_ID)+(ARNETWORKAL_MANAGER_DEFAULT_ID_MAX/2)) &&
+		len(frame.Data) > 0 {
+		b.ackLock.Lock()
+		if ack, ok := b.acks[frame.Data[0]]; ok {
+			close(ack)
+			delete(b.acks, frame.Data[0])
+		}
+		b.ackLock.Unlock()
+	}
 
 	//
 	// libARNetwork/Sources/ARNETWORK_Receiver.c#ARNETWORK_Receiver_ThreadRun
@@ -560,50 +786,218 @@ func (b *Bebop) packetReceiver(buf []byte) {
 			fmt.Println("ARNETWORK_MANAGER_INTERNAL_BUFFER_ID_PING", err)
 		}
 	}
+
+	if (frame.Type == int(ARNETWORKAL_FRAME_TYPE_DATA) ||
+		frame.Type == int(ARNETWORKAL_FRAME_TYPE_DATA_WITH_ACK)) &&
+		(frame.Id == int(BD_NET_DC_NAVDATA_ID) || frame.Id == int(BD_NET_DC_EVENT_ID)) {
+		if err := b.commandReceiver(frame.Data); err != nil {
+			fmt.Println("commandReceiver", err)
+		}
+	}
 }
 
-func (b *Bebop) StartRecording() error {
-	buf := b.videoRecord(ARCOMMANDS_ARDRONE3_MEDIARECORD_VIDEO_RECORD_START)
+func (b *Bebop) commandReceiver(buf []byte) error {
+	//
+	// libARCommands/Sources/ARCOMMANDS_Decoder.c#ARCOMMANDS_Decoder_DecodeBuffer
+	//
+	// uint8  - project
+	// uint8  - class
+	// uint16 - command
+	// ...    - arguments
+	//
+
+	if len(buf) < 4 {
+		return errors.New("command too short")
+	}
+
+	var id uint16
+	binary.Read(bytes.NewReader(buf[2:4]), binary.LittleEndian, &id)
+
+	// none of the commands we know about use the high byte
+	if id > 0xff {
+		return nil
+	}
+
+	project, class, cmd, args := buf[0], buf[1], byte(id), buf[4:]
+
+	if err := b.decodeCommand(project, class, cmd, args); err != nil {
+		return err
+	}
+
+	b.stateLock.Lock()
+	b.received[commandKey(project, class, cmd)]++
+	b.notifyStateChanged()
+	b.stateLock.Unlock()
 
-	b.write(b.networkFrameGenerator(buf, ARNETWORKAL_FRAME_TYPE_DATA, BD_NET_CD_NONACK_ID).Bytes())
 	return nil
 }
 
-func (b *Bebop) StopRecording() error {
-	buf := b.videoRecord(ARCOMMANDS_ARDRONE3_MEDIARECORD_VIDEO_RECORD_STOP)
+func (b *Bebop) decodeCommand(project byte, class byte, cmd byte, args []byte) error {
+	switch project {
+	case ARCOMMANDS_ID_PROJECT_ARDRONE3:
+		switch class {
+		case ARCOMMANDS_ID_ARDRONE3_CLASS_CAMERASTATE:
+			return b.decodeCameraState(cmd, args)
+		case ARCOMMANDS_ID_ARDRONE3_CLASS_MEDIARECORDSTATE:
+			return b.decodeMediaRecordState(cmd, args)
+		case ARCOMMANDS_ID_ARDRONE3_CLASS_MEDIARECORDEVENT:
+			return b.decodeMediaRecordEvent(cmd, args)
+		case ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSETTINGSSTATE:
+			return b.decodePilotingSettingsState(cmd, args)
+		case ARCOMMANDS_ID_ARDRONE3_CLASS_SPEEDSETTINGSSTATE:
+			return b.decodeSpeedSettingsState(cmd, args)
+		case ARCOMMANDS_ID_ARDRONE3_CLASS_NETWORKSTATE:
+			return b.decodeNetworkState(cmd, args)
+		case ARCOMMANDS_ID_ARDRONE3_CLASS_NETWORKSETTINGSSTATE:
+			return b.decodeNetworkSettingsState(cmd, args)
+		case ARCOMMANDS_ID_ARDRONE3_CLASS_PICTURESETTINGSSTATE:
+			return b.decodePictureSettingsState(cmd, args)
+		case ARCOMMANDS_ID_ARDRONE3_CLASS_ANTIFLICKERINGSTATE:
+			return b.decodeAntiflickeringState(cmd, args)
+		case ARCOMMANDS_ID_ARDRONE3_CLASS_GPSSETTINGSSTATE:
+			return b.decodeGPSSettingsState(cmd, args)
+		case ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSTATE:
+			return b.decodePilotingState(cmd, args)
+		}
+	case ARCOMMANDS_ID_PROJECT_COMMON:
+		switch class {
+		case ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE:
+			return b.decodeSettingsState(cmd, args)
+		case ARCOMMANDS_ID_COMMON_CLASS_COMMONSTATE:
+			return b.decodeCommonState(cmd, args)
+		case ARCOMMANDS_ID_COMMON_CLASS_WIFISETTINGSSTATE:
+			return b.decodeWifiSettingsState(cmd, args)
+		case ARCOMMANDS_ID_COMMON_CLASS_CALIBRATIONSTATE:
+			return b.decodeCalibrationState(cmd, args)
+		case ARCOMMANDS_ID_COMMON_CLASS_MAVLINKSTATE:
+			return b.decodeMavlinkState(cmd, args)
+		case ARCOMMANDS_ID_COMMON_CLASS_FLIGHTPLANSTATE:
+			return b.decodeFlightPlanState(cmd, args)
+		case ARCOMMANDS_ID_COMMON_CLASS_FLIGHTPLANEVENT:
+			return b.decodeFlightPlanEvent(cmd, args)
+		case ARCOMMANDS_ID_COMMON_CLASS_ARLIBSVERSIONSSTATE:
+			return b.decodeARLibsVersionsState(cmd, args)
+		case ARCOMMANDS_ID_COMMON_CLASS_OVERHEATSTATE:
+			return b.decodeOverheatState(cmd, args)
+		}
+	}
 
-	b.write(b.networkFrameGenerator(buf, ARNETWORKAL_FRAME_TYPE_DATA, BD_NET_CD_NONACK_ID).Bytes())
 	return nil
 }
 
-func (b *Bebop) videoRecord(state byte) *bytes.Buffer {
-	//
-	// ARCOMMANDS_Generator_GenerateARDrone3MediaRecordVideo
-	//
+// commandKey identifies a command received from the drone.
+func commandKey(project byte, class byte, cmd byte) uint32 {
+	return uint32(project)<<16 | uint32(class)<<8 | uint32(cmd)
+}
 
-	cmd := &bytes.Buffer{}
+// writeWithAckAndWait sends cmd on the acknowledged buffer and blocks until
+// the drone answers with the command identified by project, class and id.
+func (b *Bebop) writeWithAckAndWait(ctx context.Context, cmd *bytes.Buffer, project byte, class byte, id byte) error {
+	key := commandKey(project, class, id)
 
-	cmd.WriteByte(ARCOMMANDS_ID_PROJECT_ARDRONE3)
-	cmd.WriteByte(ARCOMMANDS_ID_ARDRONE3_CLASS_MEDIARECORD)
+	b.stateLock.RLock()
+	received := b.received[key]
+	b.stateLock.RUnlock()
 
-	tmp := &bytes.Buffer{}
-	binary.Write(tmp,
-		binary.LittleEndian,
-		uint16(ARCOMMANDS_ID_ARDRONE3_MEDIARECORD_CMD_VIDEO),
+	if err := b.writeWithAck(cmd); err != nil {
+		return err
+	}
+
+	return b.waitFor(ctx, func() bool {
+		return b.received[key] != received
+	})
+}
+
+// writeSetting sends a setting on the acknowledged buffer and waits until
+// the drone reports the resulting state with the command identified by
+// project, class and state.
+func (b *Bebop) writeSetting(project byte, class byte, id byte, stateClass byte, state byte, args ...interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	return b.writeWithAckAndWait(ctx,
+		generateCommand(project, class, id, args...),
+		project,
+		stateClass,
+		state,
 	)
+}
 
-	cmd.Write(tmp.Bytes())
+// notifyStateChanged wakes up everyone blocked in waitFor, it must be called
+// with the stateLock held.
+func (b *Bebop) notifyStateChanged() {
+	close(b.stateChanged)
+	b.stateChanged = make(chan struct{})
+}
 
-	tmp = &bytes.Buffer{}
-	binary.Write(tmp, binary.LittleEndian, uint32(state))
+// waitFor blocks until cond returns true or ctx is done. cond is evaluated
+// with the stateLock held every time decoded state changes.
+func (b *Bebop) waitFor(ctx context.Context, cond func() bool) error {
+	for {
+		b.stateLock.RLock()
+		done, changed := cond(), b.stateChanged
+		b.stateLock.RUnlock()
 
-	cmd.Write(tmp.Bytes())
+		if done {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
 
-	cmd.WriteByte(0)
+// generateCommand builds an ARCommands buffer, args are written little
+// endian and strings are NULL terminated.
+func generateCommand(project byte, class byte, id byte, args ...interface{}) *bytes.Buffer {
+	cmd := &bytes.Buffer{}
+
+	cmd.WriteByte(project)
+	cmd.WriteByte(class)
+
+	binary.Write(cmd, binary.LittleEndian, uint16(id))
+
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			cmd.WriteString(v)
+			cmd.WriteByte(0)
+		case bool:
+			binary.Write(cmd, binary.LittleEndian, bool2int8(v))
+		default:
+			binary.Write(cmd, binary.LittleEndian, v)
+		}
+	}
 
 	return cmd
 }
 
+// decodeArgs reads the little endian arguments of a received command into
+// args, *string arguments are read up to their NULL terminator.
+func decodeArgs(buf []byte, args ...interface{}) error {
+	r := bytes.NewBuffer(buf)
+
+	for _, arg := range args {
+		if s, ok := arg.(*string); ok {
+			str, err := r.ReadString(0)
+			if err != nil {
+				return err
+			}
+			*s = str[:len(str)-1]
+			continue
+		}
+
+		if err := binary.Read(r, binary.LittleEndian, arg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (b *Bebop) Video() chan []byte {
 	return b.video
 }
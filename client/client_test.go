@@ -0,0 +1,1456 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/platforms/parrot/bebop/internal/ftp/ftptest"
+)
+
+func initTestBebop() (*Bebop, chan []byte) {
+	b := New()
+	b.writeChan = make(chan []byte, 16)
+	return b, b.writeChan
+}
+
+func TestBebopGenerateCommand(t *testing.T) {
+	cmd := generateCommand(1, 2, 3, int8(-1), uint16(2), true, "ab", float32(1))
+	gobottest.Assert(t, cmd.Bytes(), []byte{
+		1, 2, 3, 0,
+		0xff,
+		2, 0,
+		1,
+		'a', 'b', 0,
+		0, 0, 0x80, 0x3f,
+	})
+}
+
+func TestBebopDecodeArgs(t *testing.T) {
+	var (
+		i int8
+		u uint16
+		s string
+		f float32
+	)
+	err := decodeArgs([]byte{0xff, 2, 0, 'a', 'b', 0, 0, 0, 0x80, 0x3f}, &i, &u, &s, &f)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, i, int8(-1))
+	gobottest.Assert(t, u, uint16(2))
+	gobottest.Assert(t, s, "ab")
+	gobottest.Assert(t, f, float32(1))
+
+	gobottest.Refute(t, decodeArgs([]byte{1}, &u), nil)
+}
+
+func TestBebopCommandReceiverTooShort(t *testing.T) {
+	b, _ := initTestBebop()
+	gobottest.Refute(t, b.commandReceiver([]byte{1, 25}), nil)
+}
+
+func TestBebopCameraOrientationV2(t *testing.T) {
+	b, c := initTestBebop()
+	gobottest.Assert(t, b.CameraOrientationV2(-90, 0), nil)
+
+	frame := NewNetworkFrame(<-c)
+	gobottest.Assert(t, frame.Id, int(BD_NET_CD_NONACK_ID))
+	gobottest.Assert(t, frame.Data, generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_CAMERA,
+		ARCOMMANDS_ID_ARDRONE3_CAMERA_CMD_ORIENTATIONV2,
+		float32(-90),
+		float32(0),
+	).Bytes())
+}
+
+func TestBebopDecodeCameraState(t *testing.T) {
+	b, _ := initTestBebop()
+
+	for _, cmd := range []*bytes.Buffer{
+		generateCommand(ARCOMMANDS_ID_PROJECT_ARDRONE3, ARCOMMANDS_ID_ARDRONE3_CLASS_CAMERASTATE,
+			ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_ORIENTATIONV2, float32(-45), float32(10)),
+		generateCommand(ARCOMMANDS_ID_PROJECT_ARDRONE3, ARCOMMANDS_ID_ARDRONE3_CLASS_CAMERASTATE,
+			ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_DEFAULTCAMERAORIENTATION, int8(-5), int8(0)),
+		generateCommand(ARCOMMANDS_ID_PROJECT_ARDRONE3, ARCOMMANDS_ID_ARDRONE3_CLASS_CAMERASTATE,
+			ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_VELOCITYRANGE, float32(20), float32(30)),
+	} {
+		gobottest.Assert(t, b.commandReceiver(cmd.Bytes()), nil)
+	}
+
+	gobottest.Assert(t, b.CameraState(), CameraState{
+		Tilt:         -45,
+		Pan:          10,
+		DefaultTilt:  -5,
+		MaxTiltSpeed: 20,
+		MaxPanSpeed:  30,
+	})
+}
+
+func TestBebopTakePictureAndWait(t *testing.T) {
+	b, c := initTestBebop()
+
+	go ackFrames(b, c, func(cmd []byte) {
+		gobottest.Assert(t, cmd, generateCommand(
+			ARCOMMANDS_ID_PROJECT_ARDRONE3,
+			ARCOMMANDS_ID_ARDRONE3_CLASS_MEDIARECORD,
+			ARCOMMANDS_ID_ARDRONE3_MEDIARECORD_CMD_PICTUREV2,
+		).Bytes())
+		b.commandReceiver(generateCommand(
+			ARCOMMANDS_ID_PROJECT_ARDRONE3,
+			ARCOMMANDS_ID_ARDRONE3_CLASS_MEDIARECORDEVENT,
+			ARCOMMANDS_ID_ARDRONE3_MEDIARECORDEVENT_CMD_PICTUREEVENTCHANGED,
+			uint32(ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_EVENT_FAILED),
+			uint32(ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_MEMORYFULL),
+		).Bytes())
+	})
+
+	event, err := b.TakePictureAndWait(context.Background())
+	gobottest.Assert(t, event.Event, ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_EVENT_FAILED)
+	gobottest.Assert(t, err, errors.New("picture failed: memory full"))
+}
+
+func TestBebopTakePictureAndWaitTimeout(t *testing.T) {
+	b, c := initTestBebop()
+	go ackFrames(b, c, func(cmd []byte) {})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := b.TakePictureAndWait(ctx)
+	gobottest.Assert(t, err, context.DeadlineExceeded)
+}
+
+func TestBebopDecodePictureState(t *testing.T) {
+	b, _ := initTestBebop()
+
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_MEDIARECORDSTATE,
+		ARCOMMANDS_ID_ARDRONE3_MEDIARECORDSTATE_CMD_PICTURESTATECHANGEDV2,
+		uint32(ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_STATE_BUSY),
+		uint32(ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_ERROR_OK),
+	).Bytes())
+
+	gobottest.Assert(t, b.PictureState(), PictureState{
+		State: ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_STATE_BUSY,
+	})
+}
+
+// ackFrames acknowledges every frame written by b on the acknowledged buffer
+// and hands its command to f.
+func ackFrames(b *Bebop, c chan []byte, f func(cmd []byte)) {
+	for buf := range c {
+		frame := NewNetworkFrame(buf)
+		if frame.Id != int(BD_NET_CD_ACK_ID) {
+			continue
+		}
+		b.packetReceiver(b.createAck(frame).Bytes())
+		f(frame.Data)
+	}
+}
+
+func videoEvent(event byte, reason byte) []byte {
+	return generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_MEDIARECORDEVENT,
+		ARCOMMANDS_ID_ARDRONE3_MEDIARECORDEVENT_CMD_VIDEOEVENTCHANGED,
+		uint32(event),
+		uint32(reason),
+	).Bytes()
+}
+
+func TestBebopStartRecording(t *testing.T) {
+	b, c := initTestBebop()
+
+	go ackFrames(b, c, func(cmd []byte) {
+		gobottest.Assert(t, cmd, generateCommand(
+			ARCOMMANDS_ID_PROJECT_ARDRONE3,
+			ARCOMMANDS_ID_ARDRONE3_CLASS_MEDIARECORD,
+			ARCOMMANDS_ID_ARDRONE3_MEDIARECORD_CMD_VIDEOV2,
+			uint32(ARCOMMANDS_ARDRONE3_MEDIARECORD_VIDEOV2_RECORD_START),
+		).Bytes())
+		b.commandReceiver(videoEvent(
+			ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_EVENT_START,
+			ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_OK,
+		))
+	})
+
+	gobottest.Assert(t, b.StartRecording(), nil)
+}
+
+func TestBebopStartRecordingFailed(t *testing.T) {
+	b, c := initTestBebop()
+
+	go ackFrames(b, c, func(cmd []byte) {
+		b.commandReceiver(videoEvent(
+			ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_EVENT_FAILED,
+			ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_MEMORYFULL,
+		))
+	})
+
+	gobottest.Assert(t, b.StartRecording(), errors.New("video recording failed: no storage left"))
+}
+
+func TestBebopWriteWithAckNotAcknowledged(t *testing.T) {
+	b, c := initTestBebop()
+
+	gobottest.Assert(t, b.writeWithAck(generateCommand(0, 0, 0)), ErrNoAck)
+	gobottest.Assert(t, len(c), ackRetries)
+}
+
+func TestBebopSetMaxAltitude(t *testing.T) {
+	b, c := initTestBebop()
+
+	go ackFrames(b, c, func(cmd []byte) {
+		var altitude float32
+		decodeArgs(cmd[4:], &altitude)
+		b.commandReceiver(generateCommand(
+			ARCOMMANDS_ID_PROJECT_ARDRONE3,
+			ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSETTINGSSTATE,
+			ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_MAXALTITUDECHANGED,
+			altitude,
+			float32(0.5),
+			float32(150),
+		).Bytes())
+	})
+
+	setting, err := b.SetMaxAltitude(30)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, setting, FloatSetting{Current: 30, Min: 0.5, Max: 150})
+	gobottest.Assert(t, b.PilotingSettings().MaxAltitude, setting)
+
+	_, err = b.SetMaxAltitude(200)
+	gobottest.Assert(t, err, errors.New("max altitude 200 is out of range [0.5, 150]"))
+}
+
+func TestBebopDecodePilotingSettingsState(t *testing.T) {
+	b, _ := initTestBebop()
+
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSETTINGSSTATE,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_NOFLYOVERMAXDISTANCECHANGED,
+		uint8(1),
+	).Bytes())
+
+	gobottest.Assert(t, b.PilotingSettings().NoFlyOverMaxDistance, true)
+	gobottest.Assert(t, b.PilotingSettings().BankedTurn, false)
+}
+
+// echoSettings answers every float setting sent by b with its *Changed state
+// using a range of [0, 100].
+func echoSettings(b *Bebop, c chan []byte) {
+	states := map[byte]byte{
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSETTINGS: ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSETTINGSSTATE,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_SPEEDSETTINGS:    ARCOMMANDS_ID_ARDRONE3_CLASS_SPEEDSETTINGSSTATE,
+	}
+
+	ackFrames(b, c, func(cmd []byte) {
+		var value float32
+		decodeArgs(cmd[4:], &value)
+		b.commandReceiver(generateCommand(cmd[0], states[cmd[1]], cmd[2], value, float32(0), float32(100)).Bytes())
+	})
+}
+
+func TestBebopSetSpeedProfile(t *testing.T) {
+	b, c := initTestBebop()
+	go echoSettings(b, c)
+
+	profile := SpeedProfile{
+		MaxTilt:                   5,
+		MaxVerticalSpeed:          0.5,
+		MaxRotationSpeed:          30,
+		MaxPitchRollRotationSpeed: 40,
+	}
+	gobottest.Assert(t, b.SetSpeedProfile(profile), nil)
+	gobottest.Assert(t, b.SpeedProfile(), profile)
+	gobottest.Assert(t, b.SpeedSettings().MaxRotationSpeed, FloatSetting{Current: 30, Max: 100})
+
+	profile.MaxRotationSpeed = 200
+	gobottest.Assert(t, b.SetSpeedProfile(profile), errors.New("max rotation speed 200 is out of range [0, 100]"))
+	gobottest.Assert(t, b.SpeedProfile().MaxRotationSpeed, float32(30))
+}
+
+func TestBebopSetSpeedProfileNotReported(t *testing.T) {
+	b, c := initTestBebop()
+
+	profile := SpeedProfile{MaxTilt: 5, MaxVerticalSpeed: 0.5, MaxRotationSpeed: 30, MaxPitchRollRotationSpeed: 40}
+	gobottest.Assert(t, b.SetSpeedProfile(profile), ErrNoAck)
+
+	// the zero profile is not restored
+	for len(c) > 0 {
+		gobottest.Assert(t, NewNetworkFrame(<-c).Data, generateCommand(
+			ARCOMMANDS_ID_PROJECT_ARDRONE3,
+			ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSETTINGS,
+			ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD_MAXTILT,
+			float32(5),
+		).Bytes())
+	}
+}
+
+func TestBebopSetSpeedProfileRollbackFailed(t *testing.T) {
+	b, c := initTestBebop()
+
+	b.stateLock.Lock()
+	b.pilotingSettings.MaxTilt = FloatSetting{Current: 10, Max: 35}
+	b.speedSettings.MaxVerticalSpeed = FloatSetting{Current: 1, Max: 6}
+	b.speedSettings.MaxRotationSpeed = FloatSetting{Current: 50, Max: 200}
+	b.speedSettings.MaxPitchRollRotationSpeed = FloatSetting{Current: 100, Max: 300}
+	b.stateLock.Unlock()
+
+	profile := SpeedProfile{MaxTilt: 5, MaxVerticalSpeed: 0.5, MaxRotationSpeed: 30, MaxPitchRollRotationSpeed: 40}
+	err := b.SetSpeedProfile(profile)
+	gobottest.Assert(t, errors.Is(err, ErrNoAck), true)
+	gobottest.Assert(t, err.Error(), "command was not acknowledged by the drone, "+
+		"restoring the previous speed profile failed: command was not acknowledged by the drone")
+	gobottest.Assert(t, len(c), 2*ackRetries)
+}
+
+func TestBebopWifiScan(t *testing.T) {
+	b, c := initTestBebop()
+
+	go ackFrames(b, c, func(cmd []byte) {
+		for _, n := range []WifiNetwork{
+			{SSID: "event", RSSI: -40, Band: ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_2_4GHZ, Channel: 6},
+			{SSID: "cafe", RSSI: -70, Band: ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_2_4GHZ, Channel: 1},
+		} {
+			b.commandReceiver(generateCommand(
+				ARCOMMANDS_ID_PROJECT_ARDRONE3,
+				ARCOMMANDS_ID_ARDRONE3_CLASS_NETWORKSTATE,
+				ARCOMMANDS_ID_ARDRONE3_NETWORKSTATE_CMD_WIFISCANLISTCHANGED,
+				n.SSID, n.RSSI, uint32(n.Band), n.Channel,
+			).Bytes())
+		}
+		b.commandReceiver(generateCommand(
+			ARCOMMANDS_ID_PROJECT_ARDRONE3,
+			ARCOMMANDS_ID_ARDRONE3_CLASS_NETWORKSTATE,
+			ARCOMMANDS_ID_ARDRONE3_NETWORKSTATE_CMD_ALLWIFISCANCHANGED,
+		).Bytes())
+	})
+
+	networks, err := b.WifiScan(context.Background(), ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_2_4GHZ)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(networks), 2)
+	gobottest.Assert(t, networks[0], WifiNetwork{SSID: "event", RSSI: -40, Channel: 6})
+}
+
+func TestLeastCongestedChannel(t *testing.T) {
+	networks := []WifiNetwork{
+		{RSSI: -40, Channel: 6},
+		{RSSI: -50, Channel: 1},
+		{RSSI: -30, Band: ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_5GHZ, Channel: 36},
+	}
+	channels := []WifiChannel{
+		{Channel: 1, Indoor: true, Outdoor: true},
+		{Channel: 6, Indoor: true, Outdoor: true},
+		{Channel: 11, Indoor: true, Outdoor: true},
+		{Channel: 13, Indoor: true},
+		{Band: ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_5GHZ, Channel: 36, Indoor: true, Outdoor: true},
+		{Band: ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_5GHZ, Channel: 40, Indoor: true},
+	}
+
+	c, err := LeastCongestedChannel(networks, channels, ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_2_4GHZ, true)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, c.Channel, byte(11))
+
+	c, err = LeastCongestedChannel(networks, channels, ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_2_4GHZ, false)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, c.Channel, byte(11))
+
+	c, err = LeastCongestedChannel(networks, channels, ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_5GHZ, false)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, c.Channel, byte(40))
+
+	_, err = LeastCongestedChannel(networks, nil, ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_ALL, false)
+	gobottest.Assert(t, err, ErrNoWifiChannel)
+}
+
+func TestBebopSetPictureFormat(t *testing.T) {
+	b, c := initTestBebop()
+
+	go ackFrames(b, c, func(cmd []byte) {
+		b.commandReceiver(generateCommand(
+			ARCOMMANDS_ID_PROJECT_ARDRONE3,
+			ARCOMMANDS_ID_ARDRONE3_CLASS_PICTURESETTINGSSTATE,
+			cmd[2],
+			cmd[4:],
+		).Bytes())
+	})
+
+	format, err := b.SetPictureFormat(ARCOMMANDS_ARDRONE3_PICTURESETTINGS_PICTUREFORMATSELECTION_TYPE_RAW)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, format, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_PICTUREFORMATSELECTION_TYPE_RAW)
+
+	_, err = b.SetPictureFormat(ARCOMMANDS_ARDRONE3_PICTURESETTINGS_PICTUREFORMATSELECTION_TYPE_MAX)
+	gobottest.Assert(t, err, errors.New("picture format 4 is not supported"))
+}
+
+func TestBebopDecodePictureSettingsState(t *testing.T) {
+	b, _ := initTestBebop()
+
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PICTURESETTINGSSTATE,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_TIMELAPSECHANGED,
+		uint8(1), float32(10), float32(8), float32(300),
+	).Bytes())
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PICTURESETTINGSSTATE,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEOFRAMERATECHANGED,
+		uint32(ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOFRAMERATE_FRAMERATE_24_FPS),
+	).Bytes())
+
+	settings := b.PictureSettings()
+	gobottest.Assert(t, settings.Timelapse, true)
+	gobottest.Assert(t, settings.TimelapseInterval, FloatSetting{Current: 10, Min: 8, Max: 300})
+	gobottest.Assert(t, settings.VideoFramerate, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOFRAMERATE_FRAMERATE_24_FPS)
+
+	_, err := b.SetTimelapse(true, 5)
+	gobottest.Assert(t, err, errors.New("timelapse interval 5 is out of range [8, 300]"))
+}
+
+func TestElectricFrequencyForLocale(t *testing.T) {
+	for locale, frequency := range map[string]byte{
+		"en_US.UTF-8": ARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE_SIXTYHERTZ,
+		"sr-Latn-RS":  ARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE_FIFTYHERTZ,
+		"fr-FR":       ARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE_FIFTYHERTZ,
+		"de_de":       ARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE_FIFTYHERTZ,
+	} {
+		f, ok, err := ElectricFrequencyForLocale(locale)
+		gobottest.Assert(t, err, nil)
+		gobottest.Assert(t, ok, true)
+		gobottest.Assert(t, f, frequency)
+	}
+
+	// mixed frequencies or no region
+	for _, locale := range []string{"ja_JP", "ja", "en", "C", "POSIX", "es-419"} {
+		_, ok, err := ElectricFrequencyForLocale(locale)
+		gobottest.Assert(t, err, nil)
+		gobottest.Assert(t, ok, false)
+	}
+
+	_, _, err := ElectricFrequencyForLocale("en_1A")
+	gobottest.Refute(t, err, nil)
+}
+
+func TestBebopLocaleAntiflickering(t *testing.T) {
+	b, c := initTestBebop()
+
+	go ackFrames(b, c, func(cmd []byte) {
+		b.commandReceiver(generateCommand(
+			ARCOMMANDS_ID_PROJECT_ARDRONE3,
+			ARCOMMANDS_ID_ARDRONE3_CLASS_ANTIFLICKERINGSTATE,
+			cmd[2],
+			cmd[4:],
+		).Bytes())
+	})
+
+	gobottest.Assert(t, b.LocaleAntiflickering("en_US"), nil)
+	gobottest.Assert(t, b.AntiflickeringState(), Antiflickering{
+		ElectricFrequency: ARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE_SIXTYHERTZ,
+		Mode:              ARCOMMANDS_ARDRONE3_ANTIFLICKERING_SETMODE_VALUE_AUTO,
+	})
+}
+
+func TestBebopSetHome(t *testing.T) {
+	b, c := initTestBebop()
+
+	go ackFrames(b, c, func(cmd []byte) {
+		b.commandReceiver(generateCommand(
+			ARCOMMANDS_ID_PROJECT_ARDRONE3,
+			ARCOMMANDS_ID_ARDRONE3_CLASS_GPSSETTINGSSTATE,
+			ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_HOMECHANGED,
+			cmd[4:],
+		).Bytes())
+	})
+
+	home, err := b.SetHome(48.8788, 2.3675, 35)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, home, Location{Latitude: 48.8788, Longitude: 2.3675, Altitude: 35})
+	gobottest.Assert(t, b.GPSSettings().Home, home)
+
+	_, err = b.HomeType(ARCOMMANDS_ARDRONE3_GPSSETTINGS_HOMETYPE_TYPE_MAX)
+	gobottest.Assert(t, err, errors.New("home type 2 is not supported"))
+}
+
+func TestBebopDecodeGPSSettingsState(t *testing.T) {
+	b, _ := initTestBebop()
+
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_GPSSETTINGSSTATE,
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_GPSFIXSTATECHANGED,
+		uint8(1),
+	).Bytes())
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_GPSSETTINGSSTATE,
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_GPSUPDATESTATECHANGED,
+		uint32(ARCOMMANDS_ARDRONE3_GPSSETTINGSSTATE_GPSUPDATESTATECHANGED_STATE_INPROGRESS),
+	).Bytes())
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_GPSSETTINGSSTATE,
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_RETURNHOMEDELAYCHANGED,
+		uint16(60),
+	).Bytes())
+
+	gobottest.Assert(t, b.GPSSettings(), GPSSettings{
+		Fixed:           true,
+		UpdateState:     ARCOMMANDS_ARDRONE3_GPSSETTINGSSTATE_GPSUPDATESTATECHANGED_STATE_INPROGRESS,
+		ReturnHomeDelay: 60,
+	})
+}
+
+func calibrationState(cmd byte, args ...interface{}) []byte {
+	return generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_CALIBRATIONSTATE,
+		cmd,
+		args...,
+	).Bytes()
+}
+
+func TestBebopMagnetoCalibration(t *testing.T) {
+	b, c := initTestBebop()
+
+	go ackFrames(b, c, func(cmd []byte) {
+		b.commandReceiver(calibrationState(
+			ARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD_MAGNETOCALIBRATIONSTARTEDCHANGED,
+			cmd[4],
+		))
+	})
+
+	gobottest.Assert(t, b.StartMagnetoCalibration(), nil)
+	step := b.MagnetoCalibrationState()
+	gobottest.Assert(t, step.Started, true)
+
+	b.commandReceiver(calibrationState(
+		ARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD_MAGNETOCALIBRATIONAXISTOCALIBRATECHANGED,
+		uint32(ARCOMMANDS_COMMON_CALIBRATIONSTATE_MAGNETOCALIBRATIONAXISTOCALIBRATECHANGED_AXIS_YAXIS),
+	))
+
+	step, err := b.NextMagnetoCalibrationStep(context.Background(), step)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, step.Axis, ARCOMMANDS_COMMON_CALIBRATIONSTATE_MAGNETOCALIBRATIONAXISTOCALIBRATECHANGED_AXIS_YAXIS)
+
+	b.commandReceiver(calibrationState(
+		ARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD_MAGNETOCALIBRATIONSTATECHANGED,
+		uint8(1), uint8(0), uint8(0), uint8(1),
+	))
+
+	step, err = b.NextMagnetoCalibrationStep(context.Background(), step)
+	gobottest.Assert(t, err, ErrMagnetoCalibrationFailed)
+	gobottest.Assert(t, step.XAxis, true)
+	gobottest.Assert(t, step.Done(), false)
+
+	gobottest.Assert(t, b.AbortMagnetoCalibration(), nil)
+	gobottest.Assert(t, b.MagnetoCalibrationState().Started, false)
+}
+
+func TestBebopUploadFlightPlan(t *testing.T) {
+	s := ftptest.NewServer()
+	defer s.Close()
+
+	b, _ := initTestBebop()
+	host, port, _ := net.SplitHostPort(s.Addr)
+	b.IP = host
+	b.FlightPlanPort, _ = strconv.Atoi(port)
+
+	gobottest.Assert(t, b.UploadFlightPlan(context.Background(), bytes.NewBufferString("QGC WPL 120\n")), nil)
+
+	data, ok := s.File(FlightPlanFile)
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, string(data), "QGC WPL 120\n")
+}
+
+func TestBebopStartFlightPlan(t *testing.T) {
+	b, c := initTestBebop()
+
+	go ackFrames(b, c, func(cmd []byte) {
+		gobottest.Assert(t, cmd, generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_MAVLINK,
+			ARCOMMANDS_ID_COMMON_MAVLINK_CMD_START,
+			FlightPlanFile,
+			uint32(ARCOMMANDS_COMMON_MAVLINK_START_TYPE_FLIGHTPLAN),
+		).Bytes())
+		b.commandReceiver(generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_MAVLINKSTATE,
+			ARCOMMANDS_ID_COMMON_MAVLINKSTATE_CMD_MAVLINKFILEPLAYINGSTATECHANGED,
+			uint32(ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKFILEPLAYINGSTATECHANGED_STATE_PLAYING),
+			FlightPlanFile,
+			uint32(ARCOMMANDS_COMMON_MAVLINK_START_TYPE_FLIGHTPLAN),
+		).Bytes())
+	})
+
+	gobottest.Assert(t, b.StartFlightPlan(), nil)
+	gobottest.Assert(t, b.FlightPlanState().File, FlightPlanFile)
+}
+
+func TestBebopStartFlightPlanFailed(t *testing.T) {
+	b, c := initTestBebop()
+
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_FLIGHTPLANSTATE,
+		ARCOMMANDS_ID_COMMON_FLIGHTPLANSTATE_CMD_COMPONENTSTATELISTCHANGED,
+		uint32(ARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT_GPS),
+		uint8(0),
+	).Bytes())
+
+	go ackFrames(b, c, func(cmd []byte) {
+		b.commandReceiver(generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_FLIGHTPLANEVENT,
+			ARCOMMANDS_ID_COMMON_FLIGHTPLANEVENT_CMD_STARTINGERROREVENT,
+		).Bytes())
+	})
+
+	gobottest.Assert(t, b.StartFlightPlan(), errors.New("flight plan failed: drone refused to start, gps not ready"))
+}
+
+func TestMissionMarshalText(t *testing.T) {
+	text, err := NewMission().
+		TakeOff(5).
+		Waypoint(48.8788, 2.3675, 10, 90).
+		TakePicture().
+		Land().
+		MarshalText()
+
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, string(text), "QGC WPL 120\n"+
+		"0\t1\t3\t22\t0.000000\t0.000000\t0.000000\t0.000000\t0.000000\t0.000000\t5.000000\t1\n"+
+		"1\t0\t3\t16\t0.000000\t0.000000\t0.000000\t90.000000\t48.878800\t2.367500\t10.000000\t1\n"+
+		"2\t0\t3\t2000\t0.000000\t1.000000\t0.000000\t0.000000\t0.000000\t0.000000\t0.000000\t1\n"+
+		"3\t0\t3\t21\t0.000000\t0.000000\t0.000000\t0.000000\t0.000000\t0.000000\t0.000000\t1\n")
+}
+
+func TestMissionValidate(t *testing.T) {
+	settings := PilotingSettings{
+		MaxAltitude:          FloatSetting{Current: 30},
+		MaxDistance:          FloatSetting{Current: 100},
+		NoFlyOverMaxDistance: true,
+	}
+	home := Location{Latitude: 48.8788, Longitude: 2.3675}
+
+	gobottest.Assert(t, NewMission().TakeOff(5).Land().Validate(settings, home), ErrEmptyMission)
+	gobottest.Assert(t, NewMission().Waypoint(48.8790, 2.3675, 20, 0).Validate(settings, home), nil)
+	gobottest.Assert(t, NewMission().Waypoint(48.8790, 2.3675, 40, 0).Validate(settings, home),
+		errors.New("mission item 0: altitude 40 is above the max altitude 30"))
+	gobottest.Assert(t, NewMission().Loiter(5).Waypoint(48.8808, 2.3675, 20, 0).Validate(settings, home),
+		errors.New("mission item 1: distance 222m from home is above the max distance 100"))
+
+	settings.NoFlyOverMaxDistance = false
+	gobottest.Assert(t, NewMission().Waypoint(48.8808, 2.3675, 20, 0).Validate(settings, home), nil)
+}
+
+func TestBebopUploadMissionUnknownHome(t *testing.T) {
+	b, _ := initTestBebop()
+
+	b.stateLock.Lock()
+	b.pilotingSettings.MaxDistance = FloatSetting{Current: 100, Min: 10, Max: 2000}
+	b.pilotingSettings.NoFlyOverMaxDistance = true
+	b.gpsSettings.Home = Location{Latitude: 500, Longitude: 500}
+	b.stateLock.Unlock()
+
+	m := NewMission().TakeOff(5).Waypoint(48.8788, 2.3675, 10, 90).Land()
+	gobottest.Assert(t, b.UploadMission(context.Background(), m), ErrUnknownHome)
+
+	// the first waypoint is not taken as home
+	b.stateLock.Lock()
+	b.gpsSettings.Home = Location{Latitude: 48.8, Longitude: 2.3}
+	b.stateLock.Unlock()
+
+	err := b.UploadMission(context.Background(), m)
+	gobottest.Refute(t, err, nil)
+	gobottest.Assert(t, strings.Contains(err.Error(), "above the max distance"), true)
+}
+
+func TestBebopStorageFull(t *testing.T) {
+	b, _ := initTestBebop()
+
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_COMMONSTATE,
+		ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_MASSSTORAGESTATELISTCHANGED,
+		uint8(0), "internal",
+	).Bytes())
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_COMMONSTATE,
+		ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_MASSSTORAGEINFOREMAININGLISTCHANGED,
+		uint32(10), uint16(2), uint32(100),
+	).Bytes())
+
+	gobottest.Assert(t, len(b.StorageFull()), 0)
+
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_COMMONSTATE,
+		ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_MASSSTORAGEINFOSTATELISTCHANGED,
+		uint8(0), uint32(8192), uint32(8192), uint8(1), uint8(1), uint8(1),
+	).Bytes())
+
+	storage := <-b.StorageFull()
+	gobottest.Assert(t, storage, Storage{
+		Name:            "internal",
+		Size:            8192,
+		Used:            8192,
+		Plugged:         true,
+		Full:            true,
+		Internal:        true,
+		Free:            10,
+		RecordingTime:   2,
+		PhotosRemaining: 100,
+		Remaining:       true,
+	})
+	gobottest.Assert(t, b.Storage(), storage)
+
+	err := b.StartRecording()
+	gobottest.Assert(t, err, &StorageFullError{Storage: storage})
+	gobottest.Assert(t, err.Error(), `no room left on storage "internal": 8192/8192 MBytes used`)
+}
+
+func TestBebopDecodeProduct(t *testing.T) {
+	b, _ := initTestBebop()
+
+	for _, cmd := range []*bytes.Buffer{
+		generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE,
+			ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTNAMECHANGED,
+			"Bebop2-042",
+		),
+		generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE,
+			ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTVERSIONCHANGED,
+			"4.0.6", "HW_04",
+		),
+		generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE,
+			ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTSERIALHIGHCHANGED,
+			"PI04044",
+		),
+		generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE,
+			ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTSERIALLOWCHANGED,
+			"5AA6A012345",
+		),
+		generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_ARLIBSVERSIONSSTATE,
+			ARCOMMANDS_ID_COMMON_ARLIBSVERSIONSSTATE_CMD_DEVICELIBARCOMMANDSVERSION,
+			"3.10.0",
+		),
+	} {
+		gobottest.Assert(t, b.commandReceiver(cmd.Bytes()), nil)
+	}
+
+	product := b.Product()
+	gobottest.Assert(t, product, Product{
+		Name:                 "Bebop2-042",
+		SoftwareVersion:      "4.0.6",
+		HardwareVersion:      "HW_04",
+		SerialHigh:           "PI04044",
+		SerialLow:            "5AA6A012345",
+		LibARCommandsVersion: "3.10.0",
+	})
+	gobottest.Assert(t, product.Serial(), "PI040445AA6A012345")
+}
+
+func TestBebopAllSettings(t *testing.T) {
+	b, c := initTestBebop()
+
+	go ackFrames(b, c, func(cmd []byte) {
+		for _, state := range []*bytes.Buffer{
+			generateCommand(
+				ARCOMMANDS_ID_PROJECT_COMMON,
+				ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE,
+				ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTNAMECHANGED,
+				"Bebop2-042",
+			),
+			generateCommand(
+				ARCOMMANDS_ID_PROJECT_COMMON,
+				ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE,
+				ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_COUNTRYCHANGED,
+				"FR",
+			),
+			generateCommand(
+				ARCOMMANDS_ID_PROJECT_COMMON,
+				ARCOMMANDS_ID_COMMON_CLASS_WIFISETTINGSSTATE,
+				ARCOMMANDS_ID_COMMON_WIFISETTINGSSTATE_CMD_OUTDOORSETTINGSCHANGED,
+				uint8(1),
+			),
+			generateCommand(
+				ARCOMMANDS_ID_PROJECT_ARDRONE3,
+				ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSETTINGSSTATE,
+				ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_MAXALTITUDECHANGED,
+				float32(30), float32(0.5), float32(150),
+			),
+			generateCommand(
+				ARCOMMANDS_ID_PROJECT_COMMON,
+				ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE,
+				ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_ALLSETTINGSCHANGED,
+			),
+		} {
+			b.commandReceiver(state.Bytes())
+		}
+	})
+
+	settings, err := b.AllSettings(context.Background())
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, settings.Product.Name, "Bebop2-042")
+	gobottest.Assert(t, settings.Country, "FR")
+	gobottest.Assert(t, settings.WifiOutdoor, true)
+	gobottest.Assert(t, settings.Piloting.MaxAltitude, FloatSetting{Current: 30, Min: 0.5, Max: 150})
+}
+
+func TestBebopSetCountry(t *testing.T) {
+	b, c := initTestBebop()
+
+	go ackFrames(b, c, func(cmd []byte) {
+		b.commandReceiver(generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE,
+			ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_COUNTRYCHANGED,
+			cmd[4:],
+		).Bytes())
+	})
+
+	country, err := b.SetCountry("US")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, country, "US")
+}
+
+func TestBebopOverheat(t *testing.T) {
+	b, c := initTestBebop()
+	b.StopVideoOnOverheat = true
+
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_OVERHEATSTATE,
+		ARCOMMANDS_ID_COMMON_OVERHEATSTATE_CMD_OVERHEATREGULATIONCHANGED,
+		ARCOMMANDS_COMMON_OVERHEATSTATE_OVERHEATREGULATIONCHANGED_REGULATIONTYPE_SWITCHOFF,
+	).Bytes())
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_OVERHEATSTATE,
+		ARCOMMANDS_ID_COMMON_OVERHEATSTATE_CMD_OVERHEATCHANGED,
+	).Bytes())
+
+	overheat := <-b.Overheated()
+	gobottest.Assert(t, overheat, Overheat{
+		Overheating: true,
+		Regulation:  ARCOMMANDS_COMMON_OVERHEATSTATE_OVERHEATREGULATIONCHANGED_REGULATIONTYPE_SWITCHOFF,
+	})
+	gobottest.Assert(t, b.Overheat(), overheat)
+
+	frame := NewNetworkFrame(<-c)
+	gobottest.Assert(t, frame.Data, generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_MEDIASTREAMING,
+		ARCOMMANDS_ID_ARDRONE3_MEDIASTREAMING_CMD_VIDEOENABLE,
+		false,
+	).Bytes())
+}
+
+func TestBebopOverheatFlying(t *testing.T) {
+	b, c := initTestBebop()
+	b.StopVideoOnOverheat = true
+
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSTATE,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_FLYINGSTATECHANGED,
+		uint32(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_HOVERING),
+	).Bytes())
+	gobottest.Assert(t, b.FlyingState(), ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_HOVERING)
+
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_OVERHEATSTATE,
+		ARCOMMANDS_ID_COMMON_OVERHEATSTATE_CMD_OVERHEATCHANGED,
+	).Bytes())
+
+	<-b.Overheated()
+	select {
+	case <-c:
+		t.Error("video disabled while flying")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBebopReportPiloting(t *testing.T) {
+	b, c := initTestBebop()
+	b.ReportPiloting = true
+
+	reported := make(chan []byte, 4)
+	go ackFrames(b, c, func(cmd []byte) { reported <- cmd })
+
+	isPiloting := func(piloting bool) []byte {
+		return generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_CONTROLLERSTATE,
+			ARCOMMANDS_ID_COMMON_CONTROLLERSTATE_CMD_ISPILOTINGCHANGED,
+			piloting,
+		).Bytes()
+	}
+
+	gobottest.Assert(t, b.TakeOff(), nil)
+	gobottest.Assert(t, <-reported, isPiloting(true))
+
+	now := time.Now()
+	b.Forward(10)
+	b.checkPiloting(now)
+	b.Stop()
+	b.checkPiloting(now.Add(b.PilotingTimeout / 2))
+	b.checkPiloting(now.Add(b.PilotingTimeout))
+	gobottest.Assert(t, <-reported, isPiloting(false))
+
+	b.Up(10)
+	b.checkPiloting(now.Add(b.PilotingTimeout))
+	gobottest.Assert(t, <-reported, isPiloting(true))
+
+	gobottest.Assert(t, b.Land(), nil)
+	gobottest.Assert(t, <-reported, isPiloting(false))
+
+	select {
+	case cmd := <-reported:
+		t.Errorf("unexpected command %v", cmd)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBebopReportPilotingInOrder(t *testing.T) {
+	b, c := initTestBebop()
+	b.ReportPiloting = true
+
+	reported := make(chan []byte, 16)
+	go ackFrames(b, c, func(cmd []byte) {
+		// keep the reporter waiting for the ack while the session changes
+		time.Sleep(10 * time.Millisecond)
+		reported <- cmd
+	})
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		b.setPiloting(true, now)
+		b.setPiloting(false, now)
+	}
+	b.setPiloting(true, now)
+
+	var last []byte
+	for {
+		select {
+		case last = <-reported:
+			continue
+		case <-time.After(100 * time.Millisecond):
+		}
+		break
+	}
+
+	gobottest.Assert(t, last, generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_CONTROLLERSTATE,
+		ARCOMMANDS_ID_COMMON_CONTROLLERSTATE_CMD_ISPILOTINGCHANGED,
+		true,
+	).Bytes())
+}
+
+func TestBebopReportPilotingNotAcked(t *testing.T) {
+	b, c := initTestBebop()
+	b.ReportPiloting = true
+
+	reported := make(chan []byte, 4)
+	go func() {
+		sent := 0
+		for buf := range c {
+			frame := NewNetworkFrame(buf)
+			if frame.Id != int(BD_NET_CD_ACK_ID) {
+				continue
+			}
+			// the first report and its retries are lost
+			if sent++; sent <= ackRetries {
+				continue
+			}
+			b.packetReceiver(b.createAck(frame).Bytes())
+			reported <- frame.Data
+		}
+	}()
+
+	b.setPiloting(true, time.Now())
+
+	for deadline := time.Now().Add(2 * time.Second); ; {
+		b.stateLock.RLock()
+		reporting, acked := b.pilotingReporting, b.pilotingReported
+		b.stateLock.RUnlock()
+		if !reporting {
+			gobottest.Assert(t, acked, false)
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("report not given up")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the next pcmd reports it again
+	b.checkPiloting(time.Now())
+	gobottest.Assert(t, <-reported, generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_CONTROLLERSTATE,
+		ARCOMMANDS_ID_COMMON_CONTROLLERSTATE_CMD_ISPILOTINGCHANGED,
+		true,
+	).Bytes())
+}
+
+// discoveryServer answers the discovery of the drone on addr.
+func discoveryServer(t *testing.T, addr string) net.Listener {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Read(make([]byte, 1024))
+			conn.Write([]byte(`{"status": 0}`))
+			conn.Close()
+		}
+	}()
+
+	return l
+}
+
+func TestBebopReboot(t *testing.T) {
+	b, c := initTestBebop()
+
+	l := discoveryServer(t, "127.0.0.1:0")
+	addr := l.Addr().String()
+	host, port, _ := net.SplitHostPort(addr)
+	b.IP = host
+	b.DiscoveryPort, _ = strconv.Atoi(port)
+
+	restarted := make(chan struct{})
+	go ackFrames(b, c, func(cmd []byte) {
+		switch {
+		case bytes.Equal(cmd, generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_COMMON,
+			ARCOMMANDS_ID_COMMON_COMMON_CMD_REBOOT,
+		).Bytes()):
+			// a Wi-Fi hiccup before the drone goes down
+			l.Close()
+			go func() {
+				time.Sleep(reconnectInterval / 2)
+				l = discoveryServer(t, addr)
+				time.Sleep(4 * reconnectInterval)
+				l.Close()
+				time.Sleep(4 * reconnectInterval)
+				l = discoveryServer(t, addr)
+				close(restarted)
+			}()
+		case bytes.Equal(cmd, generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_COMMON,
+			ARCOMMANDS_ID_COMMON_COMMON_CMD_ALLSTATES,
+		).Bytes()):
+			select {
+			case <-restarted:
+			default:
+				t.Error("states asked before the drone restarted")
+			}
+			b.commandReceiver(generateCommand(
+				ARCOMMANDS_ID_PROJECT_COMMON,
+				ARCOMMANDS_ID_COMMON_CLASS_COMMONSTATE,
+				ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_ALLSTATESCHANGED,
+			).Bytes())
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	gobottest.Assert(t, b.Reboot(ctx), nil)
+	<-restarted
+	l.Close()
+}
+
+func TestBebopReconnectNotAcked(t *testing.T) {
+	b, c := initTestBebop()
+
+	l := discoveryServer(t, "127.0.0.1:0")
+	defer l.Close()
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	b.IP = host
+	b.DiscoveryPort, _ = strconv.Atoi(port)
+
+	// the drone answers the discovery but never acknowledges ALLSTATES
+	go func() {
+		for range c {
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	gobottest.Assert(t, b.reconnect(ctx), context.DeadlineExceeded)
+}
+
+func TestBebopRebootFlying(t *testing.T) {
+	b, _ := initTestBebop()
+
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSTATE,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_FLYINGSTATECHANGED,
+		uint32(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_FLYING),
+	).Bytes())
+
+	gobottest.Assert(t, b.Reboot(context.Background()), ErrNotLanded)
+}
+
+func TestBebopState(t *testing.T) {
+	b, _ := initTestBebop()
+
+	changes, unsubscribe := b.Subscribe()
+
+	battery := func(percent uint8) {
+		b.commandReceiver(generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_COMMONSTATE,
+			ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_BATTERYSTATECHANGED,
+			percent,
+		).Bytes())
+	}
+
+	battery(80)
+	change := <-changes
+	gobottest.Assert(t, change.Changed, StateBattery)
+	gobottest.Assert(t, change.State.Battery, uint8(80))
+	gobottest.Refute(t, change.State.Updated.Battery, time.Time{})
+	gobottest.Assert(t, change.State.Updated.Position, time.Time{})
+
+	// the same value updates the time without a change
+	battery(80)
+	gobottest.Assert(t, len(changes), 0)
+	gobottest.Assert(t, b.State().Updated.Battery.Before(change.State.Updated.Battery), false)
+
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSTATE,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_POSITIONCHANGED,
+		float64(48.8), float64(2.3), float64(40),
+	).Bytes())
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSTATE,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_ATTITUDECHANGED,
+		float32(0.1), float32(-0.1), float32(1.5),
+	).Bytes())
+
+	gobottest.Assert(t, (<-changes).Changed, StatePosition)
+	change = <-changes
+	gobottest.Assert(t, change.Changed, StateAttitude)
+
+	state := b.State()
+	gobottest.Assert(t, state, change.State)
+	gobottest.Assert(t, state.Position, Location{Latitude: 48.8, Longitude: 2.3, Altitude: 40})
+	gobottest.Assert(t, state.Attitude, Attitude{Roll: 0.1, Pitch: -0.1, Yaw: 1.5})
+
+	unsubscribe()
+	_, ok := <-changes
+	gobottest.Assert(t, ok, false)
+	battery(50)
+	unsubscribe()
+}
+
+func TestBebopSetPcmd(t *testing.T) {
+	b, _ := initTestBebop()
+	pcmd := Pcmd{Flag: 1, Roll: -20, Pitch: 50, Yaw: 10, Gaz: 30}
+
+	// the pcmd loop reads the command while another goroutine sets it
+	done := make(chan struct{})
+	go func() {
+		b.SetPcmd(pcmd)
+		close(done)
+	}()
+	b.generatePcmd()
+	<-done
+
+	gobottest.Assert(t, b.CurrentPcmd(), pcmd)
+	gobottest.Assert(t, b.generatePcmd().Bytes()[7:], append(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTING,
+		ARCOMMANDS_ID_ARDRONE3_PILOTING_CMD_PCMD,
+		uint8(1), int8(-20), int8(50), int8(10), int8(30),
+	).Bytes(), 0, 0, 0, 0))
+}
+
+func TestBebopSubscribeSamples(t *testing.T) {
+	b, _ := initTestBebop()
+
+	samples, unsubscribe := b.SubscribeSamples()
+	changes, unsubscribeChanges := b.Subscribe()
+	defer unsubscribeChanges()
+
+	rssi := generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_COMMONSTATE,
+		ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_WIFISIGNALCHANGED,
+		int16(-40),
+	).Bytes()
+	b.commandReceiver(rssi)
+	b.commandReceiver(rssi)
+
+	// the unchanged sample is only sent to the sample subscribers
+	gobottest.Assert(t, len(samples), 2)
+	gobottest.Assert(t, len(changes), 1)
+	gobottest.Assert(t, (<-samples).RSSI, int16(-40))
+
+	unsubscribe()
+	gobottest.Assert(t, (<-samples).RSSI, int16(-40))
+	_, ok := <-samples
+	gobottest.Assert(t, ok, false)
+}
+
+func TestBebopSubscribeLagging(t *testing.T) {
+	b, _ := initTestBebop()
+
+	changes, unsubscribe := b.Subscribe()
+	defer unsubscribe()
+
+	for i := 0; i < stateBuffer; i++ {
+		b.commandReceiver(generateCommand(
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_COMMONSTATE,
+			ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_WIFISIGNALCHANGED,
+			int16(-40-i),
+		).Bytes())
+	}
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSTATE,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_ALERTSTATECHANGED,
+		uint32(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_LOWBATTERY),
+	).Bytes())
+
+	gobottest.Assert(t, len(changes), stateBuffer)
+
+	// the oldest change was merged into the next one
+	gobottest.Assert(t, (<-changes).Changed, StateRSSI)
+	var change StateChange
+	for len(changes) > 0 {
+		change = <-changes
+	}
+	gobottest.Assert(t, change.Changed, StateRSSI|StateAlert)
+	gobottest.Assert(t, change.State.RSSI, int16(-40-stateBuffer+1))
+	gobottest.Assert(t, change.State.Alert, ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_LOWBATTERY)
+}
+
+func TestBebopConnection(t *testing.T) {
+	b, _ := initTestBebop()
+
+	changes, unsubscribe := b.Subscribe()
+	defer unsubscribe()
+
+	now := time.Now()
+	b.frameReceived(now)
+	change := <-changes
+	gobottest.Assert(t, change.Changed, StateConnected)
+	gobottest.Assert(t, change.State.Connected, true)
+
+	b.checkConnection(now.Add(connectionTimeout / 2))
+	gobottest.Assert(t, len(changes), 0)
+
+	b.checkConnection(now.Add(connectionTimeout))
+	change = <-changes
+	gobottest.Assert(t, change.Changed, StateConnected)
+	gobottest.Assert(t, change.State.Connected, false)
+}
+
+func flyingState(state byte) []byte {
+	return generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSTATE,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_FLYINGSTATECHANGED,
+		uint32(state),
+	).Bytes()
+}
+
+func TestBebopTakeOffAndWait(t *testing.T) {
+	b, c := initTestBebop()
+
+	takeOff := generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTING,
+		ARCOMMANDS_ID_ARDRONE3_PILOTING_CMD_TAKEOFF,
+	).Bytes()
+
+	sent := make(chan int, 1)
+	go func() {
+		n := 0
+		for buf := range c {
+			if !bytes.Equal(NewNetworkFrame(buf).Data, takeOff) {
+				continue
+			}
+			// the first command is lost
+			if n++; n == 2 {
+				b.commandReceiver(flyingState(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_TAKINGOFF))
+				b.commandReceiver(flyingState(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_HOVERING))
+				sent <- n
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*flyingStateTimeout)
+	defer cancel()
+
+	gobottest.Assert(t, b.TakeOffAndWait(ctx), nil)
+	gobottest.Assert(t, b.FlyingState(), ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_HOVERING)
+	gobottest.Assert(t, <-sent, 2)
+}
+
+func TestBebopLandAndWaitEmergency(t *testing.T) {
+	b, c := initTestBebop()
+	b.commandReceiver(flyingState(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_HOVERING))
+
+	go func() {
+		<-c
+		b.commandReceiver(flyingState(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_LANDING))
+		b.commandReceiver(flyingState(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_EMERGENCY))
+	}()
+
+	gobottest.Assert(t, b.LandAndWait(context.Background()), ErrEmergency)
+}
+
+func TestBebopWaitFlyingStateCanceled(t *testing.T) {
+	b, _ := initTestBebop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	state, err := b.WaitFlyingState(ctx, ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_HOVERING)
+	gobottest.Assert(t, err, context.Canceled)
+	gobottest.Assert(t, state, ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_LANDED)
+}
+
+func alertState(alert byte) []byte {
+	return generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSTATE,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_ALERTSTATECHANGED,
+		uint32(alert),
+	).Bytes()
+}
+
+func TestBebopAlertCutOut(t *testing.T) {
+	b, _ := initTestBebop()
+	alerts, unsubscribe := b.Alerts()
+	defer unsubscribe()
+	b.Forward(50)
+
+	b.commandReceiver(alertState(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CUT_OUT))
+
+	gobottest.Assert(t, <-alerts, Alert{
+		State:  ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CUT_OUT,
+		Action: AlertActionStop,
+	})
+	gobottest.Assert(t, b.Pcmd, Pcmd{})
+
+	// the action runs once per alert
+	b.Forward(50)
+	b.commandReceiver(alertState(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CUT_OUT))
+	select {
+	case a := <-alerts:
+		t.Errorf("unexpected alert %v", a)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBebopAlertsBackToBack(t *testing.T) {
+	b, c := initTestBebop()
+	alerts, unsubscribe := b.Alerts()
+	defer unsubscribe()
+	other, unsubscribeOther := b.Alerts()
+	unsubscribeOther()
+
+	go ackFrames(b, c, func(cmd []byte) {})
+
+	b.commandReceiver(alertState(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_LOWBATTERY))
+	b.commandReceiver(alertState(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CRITICALBATTERY))
+
+	raised := map[byte]AlertAction{}
+	for i := 0; i < 2; i++ {
+		a := <-alerts
+		raised[a.State] = a.Action
+	}
+	gobottest.Assert(t, raised, map[byte]AlertAction{
+		ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_LOWBATTERY:      AlertActionNone,
+		ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CRITICALBATTERY: AlertActionLand,
+	})
+
+	_, ok := <-other
+	gobottest.Assert(t, ok, false)
+}
+
+func TestBebopAlertNavigateHome(t *testing.T) {
+	b, c := initTestBebop()
+	alerts, unsubscribe := b.Alerts()
+	defer unsubscribe()
+	b.SetAlertPolicy(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CRITICALBATTERY, AlertActionNavigateHome)
+	b.commandReceiver(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_GPSSETTINGSSTATE,
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_GPSFIXSTATECHANGED,
+		uint8(1),
+	).Bytes())
+
+	commands := make(chan []byte, 1)
+	go ackFrames(b, c, func(cmd []byte) { commands <- cmd })
+
+	b.commandReceiver(alertState(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CRITICALBATTERY))
+
+	gobottest.Assert(t, <-commands, generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTING,
+		ARCOMMANDS_ID_ARDRONE3_PILOTING_CMD_NAVIGATEHOME,
+		true,
+	).Bytes())
+	gobottest.Assert(t, <-alerts, Alert{
+		State:  ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CRITICALBATTERY,
+		Action: AlertActionNavigateHome,
+	})
+}
+
+func TestBebopAlertNavigateHomeWithoutFix(t *testing.T) {
+	b, c := initTestBebop()
+	alerts, unsubscribe := b.Alerts()
+	defer unsubscribe()
+	b.SetAlertPolicy(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CRITICALBATTERY, AlertActionNavigateHome)
+
+	b.commandReceiver(alertState(ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CRITICALBATTERY))
+
+	gobottest.Assert(t, NewNetworkFrame(<-c).Data, generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTING,
+		ARCOMMANDS_ID_ARDRONE3_PILOTING_CMD_LANDING,
+	).Bytes())
+	gobottest.Assert(t, (<-alerts).Err, nil)
+}
@@ -0,0 +1,25 @@
+package client
+
+import "time"
+
+// frameReceived records that the drone sent a frame at now.
+func (b *Bebop) frameReceived(now time.Time) {
+	b.stateLock.Lock()
+	defer b.stateLock.Unlock()
+
+	b.lastFrame = now
+	if !b.state.Connected {
+		b.updateState(StateConnected, func(s *State) { s.Connected = true })
+	}
+}
+
+// checkConnection marks the drone as disconnected when it sent nothing for
+// connectionTimeout. It is called at every pcmd.
+func (b *Bebop) checkConnection(now time.Time) {
+	b.stateLock.Lock()
+	defer b.stateLock.Unlock()
+
+	if b.state.Connected && now.Sub(b.lastFrame) >= connectionTimeout {
+		b.updateState(StateConnected, func(s *State) { s.Connected = false })
+	}
+}
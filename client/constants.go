@@ -59,6 +59,15 @@ const (
 	ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_ALTITUDECHANGED          byte = 8
 	ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_MAX                      byte = 9
 
+	// eARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE;
+	ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_NONE            byte = 0
+	ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_USER            byte = 1
+	ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CUT_OUT         byte = 2
+	ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CRITICALBATTERY byte = 3
+	ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_LOWBATTERY      byte = 4
+	ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_TOOMUCHANGLE    byte = 5
+	ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_MAX             byte = 6
+
 	// eARCOMMANDS_ID_ARDRONE3_ANIMATIONS_CMD;
 	ARCOMMANDS_ID_ARDRONE3_ANIMATIONS_CMD_FLIP byte = 0
 	ARCOMMANDS_ID_ARDRONE3_ANIMATIONS_CMD_MAX  byte = 1
@@ -138,9 +147,111 @@ const (
 	ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_CURRENTDATECHANGED                  byte = 4
 	ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_CURRENTTIMECHANGED                  byte = 5
 	ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_MASSSTORAGEINFOREMAININGLISTCHANGED byte = 6
-	ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_WIFISIGNALCHANGED                   byte = 6
-	ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_SENSORSSTATESLISTCHANGED            byte = 7
-	ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_MAX                                 byte = 8
+	ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_WIFISIGNALCHANGED                   byte = 7
+	ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_SENSORSSTATESLISTCHANGED            byte = 8
+	ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_MAX                                 byte = 9
+
+	// eARCOMMANDS_ID_COMMON_SETTINGS_CMD
+	ARCOMMANDS_ID_COMMON_SETTINGS_CMD_ALLSETTINGS byte = 0
+	ARCOMMANDS_ID_COMMON_SETTINGS_CMD_RESET       byte = 1
+	ARCOMMANDS_ID_COMMON_SETTINGS_CMD_PRODUCTNAME byte = 2
+	ARCOMMANDS_ID_COMMON_SETTINGS_CMD_COUNTRY     byte = 3
+	ARCOMMANDS_ID_COMMON_SETTINGS_CMD_AUTOCOUNTRY byte = 4
+
+	// eARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD
+	ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_ALLSETTINGSCHANGED       byte = 0
+	ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_RESETCHANGED             byte = 1
+	ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTNAMECHANGED       byte = 2
+	ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTVERSIONCHANGED    byte = 3
+	ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTSERIALHIGHCHANGED byte = 4
+	ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTSERIALLOWCHANGED  byte = 5
+	ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_COUNTRYCHANGED           byte = 6
+	ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_AUTOCOUNTRYCHANGED       byte = 7
+
+	// eARCOMMANDS_ID_COMMON_WIFISETTINGS_CMD
+	ARCOMMANDS_ID_COMMON_WIFISETTINGS_CMD_OUTDOORSETTING byte = 0
+
+	// eARCOMMANDS_ID_COMMON_WIFISETTINGSSTATE_CMD
+	ARCOMMANDS_ID_COMMON_WIFISETTINGSSTATE_CMD_OUTDOORSETTINGSCHANGED byte = 0
+
+	// eARCOMMANDS_ID_COMMON_OVERHEAT_CMD
+	ARCOMMANDS_ID_COMMON_OVERHEAT_CMD_SWITCHOFF byte = 0
+	ARCOMMANDS_ID_COMMON_OVERHEAT_CMD_VENTILATE byte = 1
+
+	// eARCOMMANDS_ID_COMMON_OVERHEATSTATE_CMD
+	ARCOMMANDS_ID_COMMON_OVERHEATSTATE_CMD_OVERHEATCHANGED           byte = 0
+	ARCOMMANDS_ID_COMMON_OVERHEATSTATE_CMD_OVERHEATREGULATIONCHANGED byte = 1
+
+	// eARCOMMANDS_COMMON_OVERHEATSTATE_OVERHEATREGULATIONCHANGED_REGULATIONTYPE
+	ARCOMMANDS_COMMON_OVERHEATSTATE_OVERHEATREGULATIONCHANGED_REGULATIONTYPE_VENTILATION byte = 0
+	ARCOMMANDS_COMMON_OVERHEATSTATE_OVERHEATREGULATIONCHANGED_REGULATIONTYPE_SWITCHOFF   byte = 1
+	ARCOMMANDS_COMMON_OVERHEATSTATE_OVERHEATREGULATIONCHANGED_REGULATIONTYPE_MAX         byte = 2
+
+	// eARCOMMANDS_ID_COMMON_CONTROLLERSTATE_CMD
+	ARCOMMANDS_ID_COMMON_CONTROLLERSTATE_CMD_ISPILOTINGCHANGED byte = 0
+
+	// eARCOMMANDS_ID_COMMON_ARLIBSVERSIONSSTATE_CMD
+	ARCOMMANDS_ID_COMMON_ARLIBSVERSIONSSTATE_CMD_CONTROLLERLIBARCOMMANDSVERSION    byte = 0
+	ARCOMMANDS_ID_COMMON_ARLIBSVERSIONSSTATE_CMD_SKYCONTROLLERLIBARCOMMANDSVERSION byte = 1
+	ARCOMMANDS_ID_COMMON_ARLIBSVERSIONSSTATE_CMD_DEVICELIBARCOMMANDSVERSION        byte = 2
+
+	// eARCOMMANDS_ID_COMMON_CALIBRATION_CMD
+	ARCOMMANDS_ID_COMMON_CALIBRATION_CMD_MAGNETOCALIBRATION byte = 0
+
+	// eARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD
+	ARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD_MAGNETOCALIBRATIONSTATECHANGED           byte = 0
+	ARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD_MAGNETOCALIBRATIONREQUIREDSTATE          byte = 1
+	ARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD_MAGNETOCALIBRATIONAXISTOCALIBRATECHANGED byte = 2
+	ARCOMMANDS_ID_COMMON_CALIBRATIONSTATE_CMD_MAGNETOCALIBRATIONSTARTEDCHANGED         byte = 3
+
+	// eARCOMMANDS_COMMON_CALIBRATIONSTATE_MAGNETOCALIBRATIONAXISTOCALIBRATECHANGED_AXIS
+	ARCOMMANDS_COMMON_CALIBRATIONSTATE_MAGNETOCALIBRATIONAXISTOCALIBRATECHANGED_AXIS_XAXIS byte = 0
+	ARCOMMANDS_COMMON_CALIBRATIONSTATE_MAGNETOCALIBRATIONAXISTOCALIBRATECHANGED_AXIS_YAXIS byte = 1
+	ARCOMMANDS_COMMON_CALIBRATIONSTATE_MAGNETOCALIBRATIONAXISTOCALIBRATECHANGED_AXIS_ZAXIS byte = 2
+	ARCOMMANDS_COMMON_CALIBRATIONSTATE_MAGNETOCALIBRATIONAXISTOCALIBRATECHANGED_AXIS_NONE  byte = 3
+
+	// eARCOMMANDS_ID_COMMON_MAVLINK_CMD
+	ARCOMMANDS_ID_COMMON_MAVLINK_CMD_START byte = 0
+	ARCOMMANDS_ID_COMMON_MAVLINK_CMD_PAUSE byte = 1
+	ARCOMMANDS_ID_COMMON_MAVLINK_CMD_STOP  byte = 2
+
+	// eARCOMMANDS_COMMON_MAVLINK_START_TYPE
+	ARCOMMANDS_COMMON_MAVLINK_START_TYPE_FLIGHTPLAN byte = 0
+	ARCOMMANDS_COMMON_MAVLINK_START_TYPE_MAPMYHOUSE byte = 1
+	ARCOMMANDS_COMMON_MAVLINK_START_TYPE_MAX        byte = 2
+
+	// eARCOMMANDS_ID_COMMON_MAVLINKSTATE_CMD
+	ARCOMMANDS_ID_COMMON_MAVLINKSTATE_CMD_MAVLINKFILEPLAYINGSTATECHANGED byte = 0
+	ARCOMMANDS_ID_COMMON_MAVLINKSTATE_CMD_MAVLINKPLAYERRORSTATECHANGED   byte = 1
+	ARCOMMANDS_ID_COMMON_MAVLINKSTATE_CMD_MISSIONITEMEXECUTED            byte = 2
+
+	// eARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKFILEPLAYINGSTATECHANGED_STATE
+	ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKFILEPLAYINGSTATECHANGED_STATE_PLAYING byte = 0
+	ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKFILEPLAYINGSTATECHANGED_STATE_STOPPED byte = 1
+	ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKFILEPLAYINGSTATECHANGED_STATE_PAUSED  byte = 2
+	ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKFILEPLAYINGSTATECHANGED_STATE_LOADED  byte = 3
+
+	// eARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKPLAYERRORSTATECHANGED_ERROR
+	ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKPLAYERRORSTATECHANGED_ERROR_NONE             byte = 0
+	ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKPLAYERRORSTATECHANGED_ERROR_NOTINOUTDOORMODE byte = 1
+	ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKPLAYERRORSTATECHANGED_ERROR_GPSNOTFIXED      byte = 2
+	ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKPLAYERRORSTATECHANGED_ERROR_NOTENOUGHBATTERY byte = 3
+
+	// eARCOMMANDS_ID_COMMON_FLIGHTPLANSTATE_CMD
+	ARCOMMANDS_ID_COMMON_FLIGHTPLANSTATE_CMD_AVAILABILITYSTATECHANGED  byte = 0
+	ARCOMMANDS_ID_COMMON_FLIGHTPLANSTATE_CMD_COMPONENTSTATELISTCHANGED byte = 1
+	ARCOMMANDS_ID_COMMON_FLIGHTPLANSTATE_CMD_LOCKSTATECHANGED          byte = 2
+
+	// eARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT
+	ARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT_GPS                     byte = 0
+	ARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT_CALIBRATION             byte = 1
+	ARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT_MAVLINK_FILE            byte = 2
+	ARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT_TAKEOFF                 byte = 3
+	ARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT_WAYPOINTSBEYONDGEOFENCE byte = 4
+
+	// eARCOMMANDS_ID_COMMON_FLIGHTPLANEVENT_CMD
+	ARCOMMANDS_ID_COMMON_FLIGHTPLANEVENT_CMD_STARTINGERROREVENT byte = 0
+	ARCOMMANDS_ID_COMMON_FLIGHTPLANEVENT_CMD_SPEEDBRIDLEEVENT   byte = 1
 
 	// eARMEDIA_ENCAPSULER_CODEC
 	CODEC_UNKNNOWN     byte = 0
@@ -175,6 +286,240 @@ const (
 	ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGS_CMD_HULLPROTECTION   byte = 2
 	ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGS_CMD_OUTDOOR          byte = 3
 
+	ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGS_CMD_MAXPITCHROLLROTATIONSPEED byte = 4
+
+	// eARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD
+	ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_MAXVERTICALSPEEDCHANGED          byte = 0
+	ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_MAXROTATIONSPEEDCHANGED          byte = 1
+	ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_HULLPROTECTIONCHANGED            byte = 2
+	ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_OUTDOORCHANGED                   byte = 3
+	ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_MAXPITCHROLLROTATIONSPEEDCHANGED byte = 4
+
+	// eARCOMMANDS_ID_ARDRONE3_NETWORK_CMD
+	ARCOMMANDS_ID_ARDRONE3_NETWORK_CMD_WIFISCAN        byte = 0
+	ARCOMMANDS_ID_ARDRONE3_NETWORK_CMD_WIFIAUTHCHANNEL byte = 1
+
+	// eARCOMMANDS_ID_ARDRONE3_NETWORKSTATE_CMD
+	ARCOMMANDS_ID_ARDRONE3_NETWORKSTATE_CMD_WIFISCANLISTCHANGED        byte = 0
+	ARCOMMANDS_ID_ARDRONE3_NETWORKSTATE_CMD_ALLWIFISCANCHANGED         byte = 1
+	ARCOMMANDS_ID_ARDRONE3_NETWORKSTATE_CMD_WIFIAUTHCHANNELLISTCHANGED byte = 2
+	ARCOMMANDS_ID_ARDRONE3_NETWORKSTATE_CMD_ALLWIFIAUTHCHANNELCHANGED  byte = 3
+
+	// eARCOMMANDS_ID_ARDRONE3_NETWORKSETTINGS_CMD
+	ARCOMMANDS_ID_ARDRONE3_NETWORKSETTINGS_CMD_WIFISELECTION byte = 0
+
+	// eARCOMMANDS_ID_ARDRONE3_NETWORKSETTINGSSTATE_CMD
+	ARCOMMANDS_ID_ARDRONE3_NETWORKSETTINGSSTATE_CMD_WIFISELECTIONCHANGED byte = 0
+
+	// eARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND
+	ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_2_4GHZ byte = 0
+	ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_5GHZ   byte = 1
+	ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_ALL    byte = 2
+
+	// eARCOMMANDS_ARDRONE3_NETWORKSETTINGS_WIFISELECTION_TYPE
+	ARCOMMANDS_ARDRONE3_NETWORKSETTINGS_WIFISELECTION_TYPE_AUTO_ALL    byte = 0
+	ARCOMMANDS_ARDRONE3_NETWORKSETTINGS_WIFISELECTION_TYPE_AUTO_2_4GHZ byte = 1
+	ARCOMMANDS_ARDRONE3_NETWORKSETTINGS_WIFISELECTION_TYPE_AUTO_5GHZ   byte = 2
+	ARCOMMANDS_ARDRONE3_NETWORKSETTINGS_WIFISELECTION_TYPE_MANUAL      byte = 3
+
+	// eARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_PICTUREFORMATSELECTION    byte = 0
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_AUTOWHITEBALANCESELECTION byte = 1
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_EXPOSITIONSELECTION       byte = 2
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_SATURATIONSELECTION       byte = 3
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_TIMELAPSESELECTION        byte = 4
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_VIDEOAUTORECORDSELECTION  byte = 5
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_VIDEOSTABILIZATIONMODE    byte = 6
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_VIDEORECORDINGMODE        byte = 7
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_VIDEOFRAMERATE            byte = 8
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_VIDEORESOLUTIONS          byte = 9
+
+	// eARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_PICTUREFORMATCHANGED          byte = 0
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_AUTOWHITEBALANCECHANGED       byte = 1
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_EXPOSITIONCHANGED             byte = 2
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_SATURATIONCHANGED             byte = 3
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_TIMELAPSECHANGED              byte = 4
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEOAUTORECORDCHANGED        byte = 5
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEOSTABILIZATIONMODECHANGED byte = 6
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEORECORDINGMODECHANGED     byte = 7
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEOFRAMERATECHANGED         byte = 8
+	ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEORESOLUTIONSCHANGED       byte = 9
+
+	// eARCOMMANDS_ARDRONE3_PICTURESETTINGS_PICTUREFORMATSELECTION_TYPE
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_PICTUREFORMATSELECTION_TYPE_RAW          byte = 0
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_PICTUREFORMATSELECTION_TYPE_JPEG         byte = 1
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_PICTUREFORMATSELECTION_TYPE_SNAPSHOT     byte = 2
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_PICTUREFORMATSELECTION_TYPE_JPEG_FISHEYE byte = 3
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_PICTUREFORMATSELECTION_TYPE_MAX          byte = 4
+
+	// eARCOMMANDS_ARDRONE3_PICTURESETTINGS_AUTOWHITEBALANCESELECTION_TYPE
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_AUTOWHITEBALANCESELECTION_TYPE_AUTO       byte = 0
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_AUTOWHITEBALANCESELECTION_TYPE_TUNGSTEN   byte = 1
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_AUTOWHITEBALANCESELECTION_TYPE_DAYLIGHT   byte = 2
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_AUTOWHITEBALANCESELECTION_TYPE_CLOUDY     byte = 3
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_AUTOWHITEBALANCESELECTION_TYPE_COOL_WHITE byte = 4
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_AUTOWHITEBALANCESELECTION_TYPE_MAX        byte = 5
+
+	// eARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOSTABILIZATIONMODE_MODE
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOSTABILIZATIONMODE_MODE_ROLL_PITCH byte = 0
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOSTABILIZATIONMODE_MODE_PITCH      byte = 1
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOSTABILIZATIONMODE_MODE_ROLL       byte = 2
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOSTABILIZATIONMODE_MODE_NONE       byte = 3
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOSTABILIZATIONMODE_MODE_MAX        byte = 4
+
+	// eARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORECORDINGMODE_MODE
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORECORDINGMODE_MODE_QUALITY byte = 0
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORECORDINGMODE_MODE_TIME    byte = 1
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORECORDINGMODE_MODE_MAX     byte = 2
+
+	// eARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOFRAMERATE_FRAMERATE
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOFRAMERATE_FRAMERATE_24_FPS byte = 0
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOFRAMERATE_FRAMERATE_25_FPS byte = 1
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOFRAMERATE_FRAMERATE_30_FPS byte = 2
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOFRAMERATE_FRAMERATE_MAX    byte = 3
+
+	// eARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORESOLUTIONS_TYPE
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORESOLUTIONS_TYPE_REC1080_STREAM480 byte = 0
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORESOLUTIONS_TYPE_REC720_STREAM720  byte = 1
+	ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORESOLUTIONS_TYPE_MAX               byte = 2
+
+	// eARCOMMANDS_ID_ARDRONE3_ANTIFLICKERING_CMD
+	ARCOMMANDS_ID_ARDRONE3_ANTIFLICKERING_CMD_ELECTRICFREQUENCY byte = 0
+	ARCOMMANDS_ID_ARDRONE3_ANTIFLICKERING_CMD_SETMODE           byte = 1
+
+	// eARCOMMANDS_ID_ARDRONE3_ANTIFLICKERINGSTATE_CMD
+	ARCOMMANDS_ID_ARDRONE3_ANTIFLICKERINGSTATE_CMD_ELECTRICFREQUENCYCHANGED byte = 0
+	ARCOMMANDS_ID_ARDRONE3_ANTIFLICKERINGSTATE_CMD_MODECHANGED              byte = 1
+
+	// eARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE
+	ARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE_FIFTYHERTZ byte = 0
+	ARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE_SIXTYHERTZ byte = 1
+	ARCOMMANDS_ARDRONE3_ANTIFLICKERING_ELECTRICFREQUENCY_VALUE_MAX        byte = 2
+
+	// eARCOMMANDS_ARDRONE3_ANTIFLICKERING_SETMODE_VALUE
+	ARCOMMANDS_ARDRONE3_ANTIFLICKERING_SETMODE_VALUE_AUTO            byte = 0
+	ARCOMMANDS_ARDRONE3_ANTIFLICKERING_SETMODE_VALUE_FIXEDFIFTYHERTZ byte = 1
+	ARCOMMANDS_ARDRONE3_ANTIFLICKERING_SETMODE_VALUE_FIXEDSIXTYHERTZ byte = 2
+	ARCOMMANDS_ARDRONE3_ANTIFLICKERING_SETMODE_VALUE_MAX             byte = 3
+
+	// eARCOMMANDS_ID_ARDRONE3_GPSSETTINGS_CMD
+	ARCOMMANDS_ID_ARDRONE3_GPSSETTINGS_CMD_SETHOME           byte = 0
+	ARCOMMANDS_ID_ARDRONE3_GPSSETTINGS_CMD_RESETHOME         byte = 1
+	ARCOMMANDS_ID_ARDRONE3_GPSSETTINGS_CMD_SENDCONTROLLERGPS byte = 2
+	ARCOMMANDS_ID_ARDRONE3_GPSSETTINGS_CMD_HOMETYPE          byte = 3
+	ARCOMMANDS_ID_ARDRONE3_GPSSETTINGS_CMD_RETURNHOMEDELAY   byte = 4
+
+	// eARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD
+	ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_HOMECHANGED            byte = 0
+	ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_RESETHOMECHANGED       byte = 1
+	ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_GPSFIXSTATECHANGED     byte = 2
+	ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_GPSUPDATESTATECHANGED  byte = 3
+	ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_HOMETYPECHANGED        byte = 4
+	ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_RETURNHOMEDELAYCHANGED byte = 5
+
+	// eARCOMMANDS_ARDRONE3_GPSSETTINGS_HOMETYPE_TYPE
+	ARCOMMANDS_ARDRONE3_GPSSETTINGS_HOMETYPE_TYPE_TAKEOFF byte = 0
+	ARCOMMANDS_ARDRONE3_GPSSETTINGS_HOMETYPE_TYPE_PILOT   byte = 1
+	ARCOMMANDS_ARDRONE3_GPSSETTINGS_HOMETYPE_TYPE_MAX     byte = 2
+
+	// eARCOMMANDS_ARDRONE3_GPSSETTINGSSTATE_GPSUPDATESTATECHANGED_STATE
+	ARCOMMANDS_ARDRONE3_GPSSETTINGSSTATE_GPSUPDATESTATECHANGED_STATE_UPDATED    byte = 0
+	ARCOMMANDS_ARDRONE3_GPSSETTINGSSTATE_GPSUPDATESTATECHANGED_STATE_INPROGRESS byte = 1
+	ARCOMMANDS_ARDRONE3_GPSSETTINGSSTATE_GPSUPDATESTATECHANGED_STATE_FAILED     byte = 2
+
 	ARCOMMANDS_ID_ARDRONE3_MEDIASTREAMING_CMD_VIDEOENABLE     byte = 0
 	ARCOMMANDS_ID_ARDRONE3_MEDIASTREAMING_CMD_VIDEOSTREAMMODE byte = 1
+
+	// eARCOMMANDS_ID_ARDRONE3_CAMERA_CMD
+	ARCOMMANDS_ID_ARDRONE3_CAMERA_CMD_ORIENTATION   byte = 0
+	ARCOMMANDS_ID_ARDRONE3_CAMERA_CMD_ORIENTATIONV2 byte = 1
+	ARCOMMANDS_ID_ARDRONE3_CAMERA_CMD_VELOCITY      byte = 2
+
+	// eARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD
+	ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_ORIENTATION                byte = 0
+	ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_DEFAULTCAMERAORIENTATION   byte = 1
+	ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_ORIENTATIONV2              byte = 2
+	ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_DEFAULTCAMERAORIENTATIONV2 byte = 3
+	ARCOMMANDS_ID_ARDRONE3_CAMERASTATE_CMD_VELOCITYRANGE              byte = 4
+
+	// eARCOMMANDS_ID_ARDRONE3_MEDIARECORDSTATE_CMD
+	ARCOMMANDS_ID_ARDRONE3_MEDIARECORDSTATE_CMD_PICTURESTATECHANGED   byte = 0
+	ARCOMMANDS_ID_ARDRONE3_MEDIARECORDSTATE_CMD_VIDEOSTATECHANGED     byte = 1
+	ARCOMMANDS_ID_ARDRONE3_MEDIARECORDSTATE_CMD_PICTURESTATECHANGEDV2 byte = 2
+	ARCOMMANDS_ID_ARDRONE3_MEDIARECORDSTATE_CMD_VIDEOSTATECHANGEDV2   byte = 3
+
+	// eARCOMMANDS_ID_ARDRONE3_MEDIARECORDEVENT_CMD
+	ARCOMMANDS_ID_ARDRONE3_MEDIARECORDEVENT_CMD_PICTUREEVENTCHANGED byte = 0
+	ARCOMMANDS_ID_ARDRONE3_MEDIARECORDEVENT_CMD_VIDEOEVENTCHANGED   byte = 1
+
+	// eARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_STATE
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_STATE_READY        byte = 0
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_STATE_BUSY         byte = 1
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_STATE_NOTAVAILABLE byte = 2
+
+	// eARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_ERROR
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_ERROR_OK         byte = 0
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_ERROR_UNKNOWN    byte = 1
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_ERROR_CAMERA_KO  byte = 2
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_ERROR_MEMORYFULL byte = 3
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_ERROR_LOWBATTERY byte = 4
+
+	// eARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_EVENT
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_EVENT_TAKEN  byte = 0
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_EVENT_FAILED byte = 1
+
+	// eARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_OK           byte = 0
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_UNKNOWN      byte = 1
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_BUSY         byte = 2
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_NOTAVAILABLE byte = 3
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_MEMORYFULL   byte = 4
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_LOWBATTERY   byte = 5
+
+	// eARCOMMANDS_ARDRONE3_MEDIARECORD_VIDEOV2_RECORD
+	ARCOMMANDS_ARDRONE3_MEDIARECORD_VIDEOV2_RECORD_STOP  byte = 0
+	ARCOMMANDS_ARDRONE3_MEDIARECORD_VIDEOV2_RECORD_START byte = 1
+
+	// eARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_STATE
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_STATE_STOPPED      byte = 0
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_STATE_STARTED      byte = 1
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_STATE_NOTAVAILABLE byte = 2
+
+	// eARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_ERROR
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_ERROR_OK         byte = 0
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_ERROR_UNKNOWN    byte = 1
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_ERROR_CAMERA_KO  byte = 2
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_ERROR_MEMORYFULL byte = 3
+	ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_ERROR_LOWBATTERY byte = 4
+
+	// eARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_EVENT
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_EVENT_START  byte = 0
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_EVENT_STOP   byte = 1
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_EVENT_FAILED byte = 2
+
+	// eARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_OK           byte = 0
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_UNKNOWN      byte = 1
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_BUSY         byte = 2
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_NOTAVAILABLE byte = 3
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_MEMORYFULL   byte = 4
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_LOWBATTERY   byte = 5
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_AUTOSTOPPED  byte = 6
+
+	// eARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD
+	ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD_MAXALTITUDE          byte = 0
+	ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD_MAXTILT              byte = 1
+	ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD_ABSOLUTCONTROL       byte = 2
+	ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD_MAXDISTANCE          byte = 3
+	ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD_NOFLYOVERMAXDISTANCE byte = 4
+	ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD_BANKEDTURN           byte = 10
+
+	// eARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD
+	ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_MAXALTITUDECHANGED          byte = 0
+	ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_MAXTILTCHANGED              byte = 1
+	ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_ABSOLUTCONTROLCHANGED       byte = 2
+	ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_MAXDISTANCECHANGED          byte = 3
+	ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_NOFLYOVERMAXDISTANCECHANGED byte = 4
+	ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_BANKEDTURNCHANGED           byte = 10
 )
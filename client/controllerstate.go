@@ -0,0 +1,103 @@
+package client
+
+import "time"
+
+// IsPiloting tells the drone whether the user is actively piloting it, the
+// drone relies on it for its failsafes and to resume flight plans.
+//
+// When ReportPiloting is set, the client reports it on its own: a piloting
+// session starts with TakeOff or any movement command and ends with Land or
+// once no movement was requested for PilotingTimeout, such as after Stop.
+func (b *Bebop) IsPiloting(piloting bool) error {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonControllerStateIsPilotingChanged
+	//
+	// uint8 - piloting 0 when the application is not in the piloting HUD, 1 when it enters the HUD.
+	//
+
+	return b.writeWithAck(generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_CONTROLLERSTATE,
+		ARCOMMANDS_ID_COMMON_CONTROLLERSTATE_CMD_ISPILOTINGCHANGED,
+		piloting,
+	))
+}
+
+// setPiloting starts or ends the piloting session at now and reports it
+// when it changed.
+func (b *Bebop) setPiloting(piloting bool, now time.Time) {
+	if !b.ReportPiloting {
+		return
+	}
+
+	b.stateLock.Lock()
+	b.piloting = piloting
+	b.pilotingActive = now
+	b.stateLock.Unlock()
+
+	b.reportPiloting()
+}
+
+// reportPiloting sends the piloting session to the drone unless it was
+// already reported or a report is being sent. The reports are sent in order,
+// the changes made while waiting for an ack are coalesced into the latest
+// one. A report that was not acknowledged is sent again at the next pcmd.
+func (b *Bebop) reportPiloting() {
+	b.stateLock.Lock()
+	defer b.stateLock.Unlock()
+
+	if b.pilotingReporting || b.pilotingReported == b.piloting {
+		return
+	}
+	b.pilotingReporting = true
+
+	// the caller may be the pcmd loop which must not wait for the ack
+	go func() {
+		for {
+			b.stateLock.Lock()
+			piloting := b.piloting
+			if piloting == b.pilotingReported {
+				b.pilotingReporting = false
+				b.stateLock.Unlock()
+				return
+			}
+			b.stateLock.Unlock()
+
+			err := b.IsPiloting(piloting)
+
+			b.stateLock.Lock()
+			if err != nil {
+				b.pilotingReporting = false
+				b.stateLock.Unlock()
+				return
+			}
+			b.pilotingReported = piloting
+			b.stateLock.Unlock()
+		}
+	}()
+}
+
+// checkPiloting starts the piloting session on movement, ends it after
+// PilotingTimeout without movement and reports it again when the drone did
+// not acknowledge it. It is called at every pcmd.
+func (b *Bebop) checkPiloting(now time.Time) {
+	if !b.ReportPiloting {
+		return
+	}
+
+	if b.CurrentPcmd().Flag != 0 {
+		b.setPiloting(true, now)
+		return
+	}
+
+	b.stateLock.RLock()
+	inactive := b.piloting && now.Sub(b.pilotingActive) >= b.PilotingTimeout
+	b.stateLock.RUnlock()
+
+	if inactive {
+		b.setPiloting(false, now)
+		return
+	}
+
+	b.reportPiloting()
+}
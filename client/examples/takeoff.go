@@ -5,6 +5,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -21,19 +22,20 @@ func main() {
 
 	bebop.HullProtection(true)
 
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
 	fmt.Println("takeoff")
-	if err := bebop.TakeOff(); err != nil {
+	if err := bebop.TakeOffAndWait(ctx); err != nil {
 		fmt.Println(err)
 		fmt.Println("fail")
 		return
 	}
-	time.Sleep(5 * time.Second)
 	fmt.Println("land")
-	if err := bebop.Land(); err != nil {
+	if err := bebop.LandAndWait(ctx); err != nil {
 		fmt.Println(err)
 		return
 	}
 
-	time.Sleep(5 * time.Second)
 	fmt.Println("done")
 }
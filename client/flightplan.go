@@ -0,0 +1,346 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gobot.io/x/gobot/platforms/parrot/bebop/internal/ftp"
+)
+
+// FlightPlanFile is the name of the flight plan on the drone.
+const FlightPlanFile = "flightPlan.mavlink"
+
+// flightPlanTimeout is how long StartFlightPlan waits for the drone to start
+// playing the flight plan.
+const flightPlanTimeout = 5 * time.Second
+
+// FlightPlan is the state of the MAVLink flight plan player of the drone.
+type FlightPlan struct {
+	// State is one of ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKFILEPLAYINGSTATECHANGED_STATE_*
+	State byte
+	// File is the path of the flight plan being played
+	File string
+	// Type is one of ARCOMMANDS_COMMON_MAVLINK_START_TYPE_*
+	Type byte
+	// Error is one of ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKPLAYERRORSTATECHANGED_ERROR_*
+	Error byte
+	// Available is true when a flight plan can be started
+	Available bool
+	// Components tells for each of
+	// ARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT_*
+	// whether it is ready for a flight plan
+	Components map[byte]bool
+	// Locked is true when the flight plan can not be stopped by the user
+	Locked bool
+	// MissionItem is the index of the last mission item executed
+	MissionItem uint32
+	// StartingError is true when the drone refused to start the last flight
+	// plan
+	StartingError bool
+	// SpeedBridled is true when the drone limited the speed requested by the
+	// flight plan
+	SpeedBridled bool
+}
+
+var mavlinkPlayErrors = map[byte]string{
+	ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKPLAYERRORSTATECHANGED_ERROR_NOTINOUTDOORMODE: "drone is not in outdoor mode",
+	ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKPLAYERRORSTATECHANGED_ERROR_GPSNOTFIXED:      "gps is not fixed",
+	ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKPLAYERRORSTATECHANGED_ERROR_NOTENOUGHBATTERY: "battery is too low",
+}
+
+var flightPlanComponents = map[byte]string{
+	ARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT_GPS:                     "gps",
+	ARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT_CALIBRATION:             "calibration",
+	ARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT_MAVLINK_FILE:            "mavlink file",
+	ARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT_TAKEOFF:                 "takeoff",
+	ARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT_WAYPOINTSBEYONDGEOFENCE: "waypoints within geofence",
+}
+
+// Err returns nil if the flight plan can be played, otherwise the play
+// error and the components that are not ready.
+func (f FlightPlan) Err() error {
+	var reasons []string
+
+	if f.Error != ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKPLAYERRORSTATECHANGED_ERROR_NONE {
+		reason, ok := mavlinkPlayErrors[f.Error]
+		if !ok {
+			reason = fmt.Sprintf("error %d", f.Error)
+		}
+		reasons = append(reasons, reason)
+	}
+
+	if f.StartingError {
+		reasons = append(reasons, "drone refused to start")
+	}
+
+	var missing []string
+	for component, ok := range f.Components {
+		if ok {
+			continue
+		}
+		name, known := flightPlanComponents[component]
+		if !known {
+			name = fmt.Sprintf("component %d", component)
+		}
+		missing = append(missing, name+" not ready")
+	}
+	sort.Strings(missing)
+	reasons = append(reasons, missing...)
+
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("flight plan failed: %s", strings.Join(reasons, ", "))
+}
+
+// UploadFlightPlan uploads a MAVLink waypoint file in the QGroundControl
+// plain text format to the drone as FlightPlanFile.
+func (b *Bebop) UploadFlightPlan(ctx context.Context, plan io.Reader) error {
+	conn, err := ftp.Dial(ctx, net.JoinHostPort(b.IP, strconv.Itoa(b.FlightPlanPort)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Store(FlightPlanFile, plan)
+}
+
+// StartFlightPlan plays the uploaded flight plan, or resumes it when paused,
+// and blocks until the drone confirms it is playing.
+func (b *Bebop) StartFlightPlan() error {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonMavlinkStart
+	//
+	// string - filepath flight plan file path from the mavlink ftp root
+	// eARCOMMANDS_COMMON_MAVLINK_START_TYPE - type type of the played mavlink file
+	//
+
+	cmd := generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_MAVLINK,
+		ARCOMMANDS_ID_COMMON_MAVLINK_CMD_START,
+		FlightPlanFile,
+		uint32(ARCOMMANDS_COMMON_MAVLINK_START_TYPE_FLIGHTPLAN),
+	)
+
+	playing := commandKey(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_MAVLINKSTATE,
+		ARCOMMANDS_ID_COMMON_MAVLINKSTATE_CMD_MAVLINKFILEPLAYINGSTATECHANGED,
+	)
+	playError := commandKey(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_MAVLINKSTATE,
+		ARCOMMANDS_ID_COMMON_MAVLINKSTATE_CMD_MAVLINKPLAYERRORSTATECHANGED,
+	)
+	startingError := commandKey(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_FLIGHTPLANEVENT,
+		ARCOMMANDS_ID_COMMON_FLIGHTPLANEVENT_CMD_STARTINGERROREVENT,
+	)
+
+	b.stateLock.RLock()
+	playings, playErrors, startingErrors := b.received[playing], b.received[playError], b.received[startingError]
+	b.stateLock.RUnlock()
+
+	if err := b.writeWithAck(cmd); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), flightPlanTimeout)
+	defer cancel()
+
+	var failed bool
+	err := b.waitFor(ctx, func() bool {
+		failed = b.received[startingError] != startingErrors ||
+			(b.received[playError] != playErrors &&
+				b.flightPlan.Error != ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKPLAYERRORSTATECHANGED_ERROR_NONE)
+		return failed || (b.received[playing] != playings &&
+			b.flightPlan.State == ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKFILEPLAYINGSTATECHANGED_STATE_PLAYING)
+	})
+	if err != nil {
+		return fmt.Errorf("flight plan start not confirmed by the drone: %v", err)
+	}
+
+	if failed {
+		return b.FlightPlanState().Err()
+	}
+
+	return nil
+}
+
+// PauseFlightPlan pauses the flight plan being played, StartFlightPlan
+// resumes it.
+func (b *Bebop) PauseFlightPlan() error {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonMavlinkPause
+	//
+
+	return b.mavlinkCommand(ARCOMMANDS_ID_COMMON_MAVLINK_CMD_PAUSE)
+}
+
+// StopFlightPlan stops the flight plan being played.
+func (b *Bebop) StopFlightPlan() error {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonMavlinkStop
+	//
+
+	return b.mavlinkCommand(ARCOMMANDS_ID_COMMON_MAVLINK_CMD_STOP)
+}
+
+// mavlinkCommand sends a MAVLink player command and waits for the drone to
+// report the new playing state.
+func (b *Bebop) mavlinkCommand(id byte) error {
+	return b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_MAVLINK,
+		id,
+		ARCOMMANDS_ID_COMMON_CLASS_MAVLINKSTATE,
+		ARCOMMANDS_ID_COMMON_MAVLINKSTATE_CMD_MAVLINKFILEPLAYINGSTATECHANGED,
+	)
+}
+
+// FlightPlanState returns the flight plan state last reported by the drone.
+func (b *Bebop) FlightPlanState() FlightPlan {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	f := b.flightPlan
+	f.Components = make(map[byte]bool, len(b.flightPlan.Components))
+	for component, ok := range b.flightPlan.Components {
+		f.Components[component] = ok
+	}
+
+	return f
+}
+
+func (b *Bebop) decodeMavlinkState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_CommonMavlinkState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_COMMON_MAVLINKSTATE_CMD_MAVLINKFILEPLAYINGSTATECHANGED:
+		//
+		// eARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKFILEPLAYINGSTATECHANGED_STATE - state State of the mavlink
+		// string - filepath flight plan file path from the mavlink ftp root
+		// eARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKFILEPLAYINGSTATECHANGED_TYPE - type type of the played mavlink file
+		//
+		var (
+			state, fileType uint32
+			file            string
+		)
+		if err := decodeArgs(args, &state, &file, &fileType); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.flightPlan.State = byte(state)
+		b.flightPlan.File = file
+		b.flightPlan.Type = byte(fileType)
+		if b.flightPlan.State == ARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKFILEPLAYINGSTATECHANGED_STATE_PLAYING {
+			b.flightPlan.StartingError = false
+		} else {
+			b.flightPlan.SpeedBridled = false
+		}
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_COMMON_MAVLINKSTATE_CMD_MAVLINKPLAYERRORSTATECHANGED:
+		//
+		// eARCOMMANDS_COMMON_MAVLINKSTATE_MAVLINKPLAYERRORSTATECHANGED_ERROR - error State of play error
+		//
+		var playError uint32
+		if err := decodeArgs(args, &playError); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.flightPlan.Error = byte(playError)
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_COMMON_MAVLINKSTATE_CMD_MISSIONITEMEXECUTED:
+		//
+		// uint32 - idx Index of the mission item. This is the place of the mission item in the list of operations of the mission
+		//
+		var item uint32
+		if err := decodeArgs(args, &item); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.flightPlan.MissionItem = item
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
+
+func (b *Bebop) decodeFlightPlanState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_CommonFlightPlanState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_COMMON_FLIGHTPLANSTATE_CMD_AVAILABILITYSTATECHANGED,
+		ARCOMMANDS_ID_COMMON_FLIGHTPLANSTATE_CMD_LOCKSTATECHANGED:
+		//
+		// uint8 - 1 if available/locked, 0 otherwise
+		//
+		var on uint8
+		if err := decodeArgs(args, &on); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		if cmd == ARCOMMANDS_ID_COMMON_FLIGHTPLANSTATE_CMD_AVAILABILITYSTATECHANGED {
+			b.flightPlan.Available = on == 1
+		} else {
+			b.flightPlan.Locked = on == 1
+		}
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_COMMON_FLIGHTPLANSTATE_CMD_COMPONENTSTATELISTCHANGED:
+		//
+		// eARCOMMANDS_COMMON_FLIGHTPLANSTATE_COMPONENTSTATELISTCHANGED_COMPONENT - component Drone FlightPlan component id (unique)
+		// uint8 - State State of the FlightPlan component (1 if FlightPlan component is OK, otherwise 0)
+		//
+		var (
+			component uint32
+			state     uint8
+		)
+		if err := decodeArgs(args, &component, &state); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		if b.flightPlan.Components == nil {
+			b.flightPlan.Components = make(map[byte]bool)
+		}
+		b.flightPlan.Components[byte(component)] = state == 1
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
+
+func (b *Bebop) decodeFlightPlanEvent(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_CommonFlightPlanEvent*
+	//
+
+	b.stateLock.Lock()
+	defer b.stateLock.Unlock()
+
+	switch cmd {
+	case ARCOMMANDS_ID_COMMON_FLIGHTPLANEVENT_CMD_STARTINGERROREVENT:
+		b.flightPlan.StartingError = true
+	case ARCOMMANDS_ID_COMMON_FLIGHTPLANEVENT_CMD_SPEEDBRIDLEEVENT:
+		b.flightPlan.SpeedBridled = true
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package client
+
+// Location is a GPS position, the drone reports 500 for each coordinate
+// when it does not know the position.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+	// Altitude in meters
+	Altitude float64
+}
+
+// GPSSettings are the GPS and return home settings reported by the drone.
+type GPSSettings struct {
+	// Home is where the drone returns when navigating home
+	Home Location
+	// HomeType is one of ARCOMMANDS_ARDRONE3_GPSSETTINGS_HOMETYPE_TYPE_*
+	HomeType byte
+	// ReturnHomeDelay in seconds before the drone returns home after the
+	// connection is lost
+	ReturnHomeDelay uint16
+	// Fixed is true when the drone has a GPS fix
+	Fixed bool
+	// UpdateState is one of ARCOMMANDS_ARDRONE3_GPSSETTINGSSTATE_GPSUPDATESTATECHANGED_STATE_*
+	UpdateState byte
+}
+
+// GPSSettings returns the GPS settings last reported by the drone.
+func (b *Bebop) GPSSettings() GPSSettings {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.gpsSettings
+}
+
+// SetHome sets the home position and returns the home confirmed by the
+// drone, altitude is in meters.
+func (b *Bebop) SetHome(latitude float64, longitude float64, altitude float64) (Location, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3GPSSettingsSetHome
+	//
+	// double - latitude Home latitude in decimal degrees
+	// double - longitude Home longitude in decimal degrees
+	// double - altitude Home altitude in meters
+	//
+
+	settings, err := b.gpsSetting(
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGS_CMD_SETHOME,
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_HOMECHANGED,
+		latitude,
+		longitude,
+		altitude,
+	)
+	return settings.Home, err
+}
+
+// ResetHome makes the drone use its default home position again and returns
+// the home confirmed by the drone.
+func (b *Bebop) ResetHome() (Location, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3GPSSettingsResetHome
+	//
+
+	settings, err := b.gpsSetting(
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGS_CMD_RESETHOME,
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_RESETHOMECHANGED,
+	)
+	return settings.Home, err
+}
+
+// HomeType sets whether the drone returns to where it took off or to the
+// pilot, and returns the type confirmed by the drone. The pilot position is
+// sent with SendControllerGPS.
+func (b *Bebop) HomeType(homeType byte) (byte, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3GPSSettingsHomeType
+	//
+	// eARCOMMANDS_ARDRONE3_GPSSETTINGS_HOMETYPE_TYPE - type The type of the home position
+	//
+
+	if err := checkEnum("home type", homeType, ARCOMMANDS_ARDRONE3_GPSSETTINGS_HOMETYPE_TYPE_MAX); err != nil {
+		return 0, err
+	}
+
+	settings, err := b.gpsSetting(
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGS_CMD_HOMETYPE,
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_HOMETYPECHANGED,
+		uint32(homeType),
+	)
+	return settings.HomeType, err
+}
+
+// ReturnHomeDelay sets how many seconds the drone waits after losing the
+// connection before returning home, and returns the delay confirmed by the
+// drone.
+func (b *Bebop) ReturnHomeDelay(delay uint16) (uint16, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3GPSSettingsReturnHomeDelay
+	//
+	// uint16 - delay Delay in second
+	//
+
+	settings, err := b.gpsSetting(
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGS_CMD_RETURNHOMEDELAY,
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_RETURNHOMEDELAYCHANGED,
+		delay,
+	)
+	return settings.ReturnHomeDelay, err
+}
+
+// SendControllerGPS sends the position of the pilot, which the drone uses
+// as home with ARCOMMANDS_ARDRONE3_GPSSETTINGS_HOMETYPE_TYPE_PILOT.
+// Accuracies are in meters, -1 when unknown.
+func (b *Bebop) SendControllerGPS(latitude float64, longitude float64, altitude float64, horizontalAccuracy float64, verticalAccuracy float64) error {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3GPSSettingsSendControllerGPS
+	//
+	// double - latitude GPS latitude in decimal degrees
+	// double - longitude GPS longitude in decimal degrees
+	// double - altitude GPS altitude in meters
+	// double - horizontalAccuracy Horizontal Accuracy in meter ; equal -1 if no horizontal Accuracy
+	// double - verticalAccuracy Vertical Accuracy in meter ; equal -1 if no vertical Accuracy
+	//
+
+	return b.writeWithAck(generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_GPSSETTINGS,
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGS_CMD_SENDCONTROLLERGPS,
+		latitude,
+		longitude,
+		altitude,
+		horizontalAccuracy,
+		verticalAccuracy,
+	))
+}
+
+// gpsSetting sends a GPS setting and waits for the drone to report the
+// resulting state.
+func (b *Bebop) gpsSetting(id byte, state byte, args ...interface{}) (GPSSettings, error) {
+	err := b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_GPSSETTINGS,
+		id,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_GPSSETTINGSSTATE,
+		state,
+		args...,
+	)
+	if err != nil {
+		return GPSSettings{}, err
+	}
+
+	return b.GPSSettings(), nil
+}
+
+func (b *Bebop) decodeGPSSettingsState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_ARDrone3GPSSettingsState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_HOMECHANGED,
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_RESETHOMECHANGED:
+		//
+		// double - latitude Home latitude in decimal degrees
+		// double - longitude Home longitude in decimal degrees
+		// double - altitude Home altitude in meters
+		//
+		var home Location
+		if err := decodeArgs(args, &home.Latitude, &home.Longitude, &home.Altitude); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.gpsSettings.Home = home
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_GPSFIXSTATECHANGED:
+		//
+		// uint8 - fixed 1 if gps on drone is fixed, 0 otherwise
+		//
+		var fixed uint8
+		if err := decodeArgs(args, &fixed); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.gpsSettings.Fixed = fixed == 1
+		b.updateState(StateGPSFixed, func(s *State) { s.GPSFixed = fixed == 1 })
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_GPSUPDATESTATECHANGED,
+		ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_HOMETYPECHANGED:
+		//
+		// eARCOMMANDS_ARDRONE3_GPSSETTINGSSTATE_* - state or type
+		//
+		var value uint32
+		if err := decodeArgs(args, &value); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		if cmd == ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_GPSUPDATESTATECHANGED {
+			b.gpsSettings.UpdateState = byte(value)
+		} else {
+			b.gpsSettings.HomeType = byte(value)
+		}
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_GPSSETTINGSSTATE_CMD_RETURNHOMEDELAYCHANGED:
+		//
+		// uint16 - delay Delay in second
+		//
+		var delay uint16
+		if err := decodeArgs(args, &delay); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.gpsSettings.ReturnHomeDelay = delay
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,287 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// videoEventTimeout is how long StartRecording and StopRecording wait for
+// the drone to confirm the new recording state.
+const videoEventTimeout = 5 * time.Second
+
+// PictureState tells if the drone is able to take a picture.
+type PictureState struct {
+	// State is one of ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_STATE_*
+	State byte
+	// Error is one of ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_ERROR_*
+	Error byte
+}
+
+// PictureEvent is sent by the drone once a picture has been saved or failed.
+// The drone does not include the name of the saved file.
+type PictureEvent struct {
+	// Event is one of ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_EVENT_*
+	Event byte
+	// Error is one of ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_*
+	Error byte
+}
+
+var pictureEventErrors = map[byte]string{
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_OK:           "ok",
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_UNKNOWN:      "unknown error",
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_BUSY:         "picture camera is busy",
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_NOTAVAILABLE: "picture camera is not available",
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_MEMORYFULL:   "memory full",
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR_LOWBATTERY:   "battery is too low",
+}
+
+// Err returns nil if the picture was taken, otherwise the reason it failed.
+func (e PictureEvent) Err() error {
+	if e.Event == ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_EVENT_TAKEN {
+		return nil
+	}
+
+	reason, ok := pictureEventErrors[e.Error]
+	if !ok {
+		reason = fmt.Sprintf("error %d", e.Error)
+	}
+
+	return fmt.Errorf("picture failed: %s", reason)
+}
+
+// VideoState tells if the drone is recording a video.
+type VideoState struct {
+	// State is one of ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_STATE_*
+	State byte
+	// Error is one of ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_ERROR_*
+	Error byte
+}
+
+// VideoEvent is sent by the drone when a recording starts, stops or fails.
+type VideoEvent struct {
+	// Event is one of ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_EVENT_*
+	Event byte
+	// Error is one of ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_*
+	Error byte
+}
+
+var videoEventErrors = map[byte]string{
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_OK:           "ok",
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_UNKNOWN:      "unknown error",
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_BUSY:         "video camera is busy",
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_NOTAVAILABLE: "video camera is not available",
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_MEMORYFULL:   "no storage left",
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_LOWBATTERY:   "battery is too low",
+	ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR_AUTOSTOPPED:  "recording was stopped automatically",
+}
+
+// Err returns the reason the recording failed, or nil if it did not.
+func (e VideoEvent) Err() error {
+	if e.Event != ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_EVENT_FAILED {
+		return nil
+	}
+
+	reason, ok := videoEventErrors[e.Error]
+	if !ok {
+		reason = fmt.Sprintf("error %d", e.Error)
+	}
+
+	return fmt.Errorf("video recording failed: %s", reason)
+}
+
+// StartRecording starts recording video to the drones internal storage, an
+// error is returned if the drone did not confirm the recording has started.
+// A *StorageFullError is returned without asking the drone when its storage
+// has no room left.
+func (b *Bebop) StartRecording() error {
+	if s := b.Storage(); s.noRoom() {
+		return &StorageFullError{Storage: s}
+	}
+
+	return b.videoRecord(ARCOMMANDS_ARDRONE3_MEDIARECORD_VIDEOV2_RECORD_START)
+}
+
+// StopRecording stops a previously started recording, an error is returned
+// if the drone did not confirm the recording has stopped.
+func (b *Bebop) StopRecording() error {
+	return b.videoRecord(ARCOMMANDS_ARDRONE3_MEDIARECORD_VIDEOV2_RECORD_STOP)
+}
+
+func (b *Bebop) videoRecord(record byte) error {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3MediaRecordVideoV2
+	//
+	// eARCOMMANDS_ARDRONE3_MEDIARECORD_VIDEOV2_RECORD - record
+	//
+
+	cmd := generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_MEDIARECORD,
+		ARCOMMANDS_ID_ARDRONE3_MEDIARECORD_CMD_VIDEOV2,
+		uint32(record),
+	)
+
+	b.stateLock.RLock()
+	events := b.videoEvents
+	b.stateLock.RUnlock()
+
+	if err := b.writeWithAck(cmd); err != nil {
+		return err
+	}
+
+	want := ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_EVENT_START
+	if record == ARCOMMANDS_ARDRONE3_MEDIARECORD_VIDEOV2_RECORD_STOP {
+		want = ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_EVENT_STOP
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), videoEventTimeout)
+	defer cancel()
+
+	var event VideoEvent
+	err := b.waitFor(ctx, func() bool {
+		event = b.videoEvent
+		return b.videoEvents != events &&
+			(event.Event == want ||
+				event.Event == ARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_EVENT_FAILED)
+	})
+	if err != nil {
+		return fmt.Errorf("video recording state not confirmed by the drone: %v", err)
+	}
+
+	return event.Err()
+}
+
+// VideoState returns the last video recording state reported by the drone.
+func (b *Bebop) VideoState() VideoState {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.videoState
+}
+
+// TakePicture takes a picture and saves it to the drones internal storage,
+// an error is returned if the drone did not acknowledge the command.
+func (b *Bebop) TakePicture() error {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3MediaRecordPictureV2
+	//
+
+	cmd := generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_MEDIARECORD,
+		ARCOMMANDS_ID_ARDRONE3_MEDIARECORD_CMD_PICTUREV2,
+	)
+
+	return b.writeWithAck(cmd)
+}
+
+// TakePictureAndWait takes a picture and blocks until the drone reports it
+// has been saved, the picture failed or ctx is done.
+func (b *Bebop) TakePictureAndWait(ctx context.Context) (PictureEvent, error) {
+	b.stateLock.RLock()
+	events := b.pictureEvents
+	b.stateLock.RUnlock()
+
+	if err := b.TakePicture(); err != nil {
+		return PictureEvent{}, err
+	}
+
+	var event PictureEvent
+	err := b.waitFor(ctx, func() bool {
+		event = b.pictureEvent
+		return b.pictureEvents != events
+	})
+	if err != nil {
+		return PictureEvent{}, err
+	}
+
+	return event, event.Err()
+}
+
+// PictureState returns the last picture state reported by the drone.
+func (b *Bebop) PictureState() PictureState {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.picture
+}
+
+func (b *Bebop) decodeMediaRecordState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_ARDrone3MediaRecordState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_ARDRONE3_MEDIARECORDSTATE_CMD_PICTURESTATECHANGEDV2:
+		//
+		// eARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_STATE - state
+		// eARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_PICTURESTATECHANGEDV2_ERROR - error
+		//
+		var state, reason uint32
+		if err := decodeArgs(args, &state, &reason); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.picture = PictureState{State: byte(state), Error: byte(reason)}
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_MEDIARECORDSTATE_CMD_VIDEOSTATECHANGEDV2:
+		//
+		// eARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_STATE - state
+		// eARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_ERROR - error
+		//
+		var state, reason uint32
+		if err := decodeArgs(args, &state, &reason); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.videoState = VideoState{State: byte(state), Error: byte(reason)}
+		b.updateState(StateRecording, func(s *State) {
+			s.Recording = byte(state) == ARCOMMANDS_ARDRONE3_MEDIARECORDSTATE_VIDEOSTATECHANGEDV2_STATE_STARTED
+		})
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
+
+func (b *Bebop) decodeMediaRecordEvent(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_ARDrone3MediaRecordEvent*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_ARDRONE3_MEDIARECORDEVENT_CMD_PICTUREEVENTCHANGED:
+		//
+		// eARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_EVENT - event
+		// eARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_PICTUREEVENTCHANGED_ERROR - error
+		//
+		var event, reason uint32
+		if err := decodeArgs(args, &event, &reason); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.pictureEvent = PictureEvent{Event: byte(event), Error: byte(reason)}
+		b.pictureEvents++
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_MEDIARECORDEVENT_CMD_VIDEOEVENTCHANGED:
+		//
+		// eARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_EVENT - event
+		// eARCOMMANDS_ARDRONE3_MEDIARECORDEVENT_VIDEOEVENTCHANGED_ERROR - error
+		//
+		var event, reason uint32
+		if err := decodeArgs(args, &event, &reason); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.videoEvent = VideoEvent{Event: byte(event), Error: byte(reason)}
+		b.videoEvents++
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// MAVLink commands understood by the flight plan player of the Bebop.
+const (
+	MAV_CMD_NAV_WAYPOINT        uint16 = 16
+	MAV_CMD_NAV_LAND            uint16 = 21
+	MAV_CMD_NAV_TAKEOFF         uint16 = 22
+	MAV_CMD_CONDITION_DELAY     uint16 = 112
+	MAV_CMD_CONDITION_YAW       uint16 = 115
+	MAV_CMD_DO_MOUNT_CONTROL    uint16 = 205
+	MAV_CMD_IMAGE_START_CAPTURE uint16 = 2000
+	MAV_CMD_VIDEO_START_CAPTURE uint16 = 2500
+	MAV_CMD_VIDEO_STOP_CAPTURE  uint16 = 2501
+)
+
+// MAV_FRAME_GLOBAL_RELATIVE_ALT is the frame of every mission item, positions
+// are WGS84 with the altitude relative to the takeoff position.
+const MAV_FRAME_GLOBAL_RELATIVE_ALT = 3
+
+// earthRadius in meters
+const earthRadius = 6371000
+
+// MissionItem is one MAVLink command of a mission.
+type MissionItem struct {
+	Command   uint16
+	Params    [4]float32
+	Latitude  float64
+	Longitude float64
+	// Altitude in meters above the takeoff position
+	Altitude float64
+}
+
+// Mission is a flight plan built in Go, it is serialized with MarshalText to
+// the QGroundControl plain text format played by the drone.
+type Mission struct {
+	Items []MissionItem
+}
+
+var (
+	// ErrEmptyMission is returned when validating a mission without waypoints.
+	ErrEmptyMission = errors.New("mission has no waypoint")
+	// ErrUnknownHome is returned when uploading a mission while the geofence
+	// is enabled and the drone does not know its home position.
+	ErrUnknownHome = errors.New("home position is unknown, the max distance cannot be checked")
+)
+
+// NewMission returns an empty mission, items are added with the builder
+// methods which can be chained.
+func NewMission() *Mission {
+	return &Mission{}
+}
+
+func (m *Mission) add(item MissionItem) *Mission {
+	m.Items = append(m.Items, item)
+	return m
+}
+
+// TakeOff takes off and climbs to altitude in meters.
+func (m *Mission) TakeOff(altitude float64) *Mission {
+	return m.add(MissionItem{Command: MAV_CMD_NAV_TAKEOFF, Altitude: altitude})
+}
+
+// Waypoint flies to latitude and longitude at altitude in meters, facing yaw
+// degrees from north.
+func (m *Mission) Waypoint(latitude float64, longitude float64, altitude float64, yaw float32) *Mission {
+	return m.add(MissionItem{
+		Command:   MAV_CMD_NAV_WAYPOINT,
+		Params:    [4]float32{0, 0, 0, yaw},
+		Latitude:  latitude,
+		Longitude: longitude,
+		Altitude:  altitude,
+	})
+}
+
+// Loiter hovers in place for seconds.
+func (m *Mission) Loiter(seconds float32) *Mission {
+	return m.add(MissionItem{Command: MAV_CMD_CONDITION_DELAY, Params: [4]float32{seconds}})
+}
+
+// Yaw turns the drone to heading degrees from north at speed degrees/s.
+func (m *Mission) Yaw(heading float32, speed float32) *Mission {
+	return m.add(MissionItem{Command: MAV_CMD_CONDITION_YAW, Params: [4]float32{heading, speed}})
+}
+
+// TiltCamera points the camera tilt degrees up, negative values point it
+// down.
+func (m *Mission) TiltCamera(tilt float32) *Mission {
+	return m.add(MissionItem{Command: MAV_CMD_DO_MOUNT_CONTROL, Params: [4]float32{tilt}})
+}
+
+// TakePicture takes a single picture.
+func (m *Mission) TakePicture() *Mission {
+	return m.add(MissionItem{Command: MAV_CMD_IMAGE_START_CAPTURE, Params: [4]float32{0, 1}})
+}
+
+// StartVideo starts recording a video.
+func (m *Mission) StartVideo() *Mission {
+	return m.add(MissionItem{Command: MAV_CMD_VIDEO_START_CAPTURE})
+}
+
+// StopVideo stops recording the video.
+func (m *Mission) StopVideo() *Mission {
+	return m.add(MissionItem{Command: MAV_CMD_VIDEO_STOP_CAPTURE})
+}
+
+// Land lands where the drone is.
+func (m *Mission) Land() *Mission {
+	return m.add(MissionItem{Command: MAV_CMD_NAV_LAND})
+}
+
+// MarshalText serializes the mission to the QGroundControl plain text
+// format.
+func (m *Mission) MarshalText() ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	buf.WriteString("QGC WPL 120\n")
+
+	for i, item := range m.Items {
+		current := 0
+		if i == 0 {
+			current = 1
+		}
+
+		fmt.Fprintf(buf, "%d\t%d\t%d\t%d\t%f\t%f\t%f\t%f\t%f\t%f\t%f\t1\n",
+			i,
+			current,
+			MAV_FRAME_GLOBAL_RELATIVE_ALT,
+			item.Command,
+			item.Params[0],
+			item.Params[1],
+			item.Params[2],
+			item.Params[3],
+			item.Latitude,
+			item.Longitude,
+			item.Altitude,
+		)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Validate checks that every position of the mission is valid and within
+// the max altitude of settings, and within the max distance from home when
+// the geofence is enabled.
+func (m *Mission) Validate(settings PilotingSettings, home Location) error {
+	var waypoints int
+
+	for i, item := range m.Items {
+		switch item.Command {
+		case MAV_CMD_NAV_TAKEOFF, MAV_CMD_NAV_WAYPOINT:
+		default:
+			continue
+		}
+
+		if item.Altitude < 0 {
+			return fmt.Errorf("mission item %d: altitude %v is below the takeoff position", i, item.Altitude)
+		}
+		if max := settings.MaxAltitude.Current; max > 0 && item.Altitude > float64(max) {
+			return fmt.Errorf("mission item %d: altitude %v is above the max altitude %v", i, item.Altitude, max)
+		}
+
+		if item.Command != MAV_CMD_NAV_WAYPOINT {
+			continue
+		}
+		waypoints++
+
+		if math.Abs(item.Latitude) > 90 || math.Abs(item.Longitude) > 180 {
+			return fmt.Errorf("mission item %d: invalid position %v, %v", i, item.Latitude, item.Longitude)
+		}
+
+		max := settings.MaxDistance.Current
+		if !settings.NoFlyOverMaxDistance || max <= 0 {
+			continue
+		}
+		if d := distance(home.Latitude, home.Longitude, item.Latitude, item.Longitude); d > float64(max) {
+			return fmt.Errorf("mission item %d: distance %.0fm from home is above the max distance %v", i, d, max)
+		}
+	}
+
+	if waypoints == 0 {
+		return ErrEmptyMission
+	}
+
+	return nil
+}
+
+// distance returns the great circle distance in meters between two
+// positions.
+func distance(lat1 float64, lon1 float64, lat2 float64, lon2 float64) float64 {
+	rad := math.Pi / 180
+	dLat, dLon := (lat2-lat1)*rad, (lon2-lon1)*rad
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// UploadMission validates m against the piloting settings of the drone and
+// uploads it as the flight plan. Distances are measured from the home
+// position, ErrUnknownHome is returned while the drone does not know it and
+// the geofence is enabled.
+func (b *Bebop) UploadMission(ctx context.Context, m *Mission) error {
+	settings, home := b.PilotingSettings(), b.GPSSettings().Home
+	geofence := settings.NoFlyOverMaxDistance && settings.MaxDistance.Current > 0
+	if geofence && (home == (Location{}) || home.Latitude == 500) {
+		return ErrUnknownHome
+	}
+
+	if err := m.Validate(settings, home); err != nil {
+		return err
+	}
+
+	plan, err := m.MarshalText()
+	if err != nil {
+		return err
+	}
+
+	return b.UploadFlightPlan(ctx, bytes.NewReader(plan))
+}
@@ -0,0 +1,305 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// WifiNetwork is a network seen by the drone during a Wi-Fi scan.
+type WifiNetwork struct {
+	SSID string
+	// RSSI in dBm
+	RSSI int16
+	// Band is one of ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_*
+	Band    byte
+	Channel byte
+}
+
+// WifiChannel is a channel the drone is allowed to use.
+type WifiChannel struct {
+	// Band is one of ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_*
+	Band    byte
+	Channel byte
+	// Outdoor is true if the channel is authorized outdoor
+	Outdoor bool
+	// Indoor is true if the channel is authorized indoor
+	Indoor bool
+}
+
+// WifiSelection is how the drone picks its Wi-Fi channel.
+type WifiSelection struct {
+	// Type is one of ARCOMMANDS_ARDRONE3_NETWORKSETTINGS_WIFISELECTION_TYPE_*
+	Type byte
+	// Band is one of ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_*
+	Band    byte
+	Channel byte
+}
+
+// ErrNoWifiChannel is returned when no authorized channel is available.
+var ErrNoWifiChannel = errors.New("no authorized wifi channel available")
+
+// WifiScan asks the drone to scan band for Wi-Fi networks and blocks until
+// the complete list has been received or ctx is done.
+func (b *Bebop) WifiScan(ctx context.Context, band byte) ([]WifiNetwork, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3NetworkWifiScan
+	//
+	// eARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND - band The band(s) : 2.4 Ghz, 5 Ghz, or both
+	//
+
+	cmd := generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_NETWORK,
+		ARCOMMANDS_ID_ARDRONE3_NETWORK_CMD_WIFISCAN,
+		uint32(band),
+	)
+
+	b.stateLock.Lock()
+	b.wifiScan = nil
+	b.stateLock.Unlock()
+
+	err := b.writeWithAckAndWait(ctx, cmd,
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_NETWORKSTATE,
+		ARCOMMANDS_ID_ARDRONE3_NETWORKSTATE_CMD_ALLWIFISCANCHANGED,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return append([]WifiNetwork{}, b.wifiScan...), nil
+}
+
+// AuthorizedChannels asks the drone which Wi-Fi channels it may use in its
+// current country and blocks until the complete list has been received or
+// ctx is done.
+func (b *Bebop) AuthorizedChannels(ctx context.Context) ([]WifiChannel, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3NetworkWifiAuthChannel
+	//
+
+	cmd := generateCommand(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_NETWORK,
+		ARCOMMANDS_ID_ARDRONE3_NETWORK_CMD_WIFIAUTHCHANNEL,
+	)
+
+	b.stateLock.Lock()
+	b.wifiChannels = nil
+	b.stateLock.Unlock()
+
+	err := b.writeWithAckAndWait(ctx, cmd,
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_NETWORKSTATE,
+		ARCOMMANDS_ID_ARDRONE3_NETWORKSTATE_CMD_ALLWIFIAUTHCHANNELCHANGED,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return append([]WifiChannel{}, b.wifiChannels...), nil
+}
+
+// WifiSelection sets how the drone picks its Wi-Fi channel and returns the
+// selection confirmed by the drone. The channel is only used with
+// ARCOMMANDS_ARDRONE3_NETWORKSETTINGS_WIFISELECTION_TYPE_MANUAL. Changing
+// channel briefly drops the connection to the drone.
+func (b *Bebop) WifiSelection(selection byte, band byte, channel byte) (WifiSelection, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3NetworkSettingsWifiSelection
+	//
+	// eARCOMMANDS_ARDRONE3_NETWORKSETTINGS_WIFISELECTION_TYPE - type The type of wifi selection (auto, manual)
+	// eARCOMMANDS_ARDRONE3_NETWORKSETTINGS_WIFISELECTION_BAND - band The allowed band(s) : 2.4 Ghz, 5 Ghz, or all
+	// uint8 - channel The channel (not used in auto mode)
+	//
+
+	err := b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_NETWORKSETTINGS,
+		ARCOMMANDS_ID_ARDRONE3_NETWORKSETTINGS_CMD_WIFISELECTION,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_NETWORKSETTINGSSTATE,
+		ARCOMMANDS_ID_ARDRONE3_NETWORKSETTINGSSTATE_CMD_WIFISELECTIONCHANGED,
+		uint32(selection),
+		uint32(band),
+		channel,
+	)
+	if err != nil {
+		return WifiSelection{}, err
+	}
+
+	return b.WifiSelectionState(), nil
+}
+
+// WifiSelectionState returns the Wi-Fi selection last reported by the drone.
+func (b *Bebop) WifiSelectionState() WifiSelection {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.wifiSelection
+}
+
+// AutoSelectWifiChannel scans band and moves the drone to the authorized
+// channel with the least interference from other networks.
+func (b *Bebop) AutoSelectWifiChannel(ctx context.Context, band byte, outdoor bool) (WifiSelection, error) {
+	networks, err := b.WifiScan(ctx, band)
+	if err != nil {
+		return WifiSelection{}, err
+	}
+
+	channels, err := b.AuthorizedChannels(ctx)
+	if err != nil {
+		return WifiSelection{}, err
+	}
+
+	channel, err := LeastCongestedChannel(networks, channels, band, outdoor)
+	if err != nil {
+		return WifiSelection{}, err
+	}
+
+	return b.WifiSelection(
+		ARCOMMANDS_ARDRONE3_NETWORKSETTINGS_WIFISELECTION_TYPE_MANUAL,
+		channel.Band,
+		channel.Channel,
+	)
+}
+
+// LeastCongestedChannel returns the channel of band, authorized indoor or
+// outdoor, that receives the least power from networks. On 2.4 GHz networks
+// also interfere with the four channels on each side of their own.
+func LeastCongestedChannel(networks []WifiNetwork, channels []WifiChannel, band byte, outdoor bool) (WifiChannel, error) {
+	var (
+		best  WifiChannel
+		score = math.Inf(1)
+	)
+
+	for _, c := range channels {
+		if band != ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_ALL && c.Band != band {
+			continue
+		}
+		if (outdoor && !c.Outdoor) || (!outdoor && !c.Indoor) {
+			continue
+		}
+
+		s := 0.0
+		for _, n := range networks {
+			if n.Band != c.Band {
+				continue
+			}
+			s += channelOverlap(n.Band, n.Channel, c.Channel) * math.Pow(10, float64(n.RSSI)/10)
+		}
+
+		if s < score || (s == score && c.Channel < best.Channel) {
+			best, score = c, s
+		}
+	}
+
+	if math.IsInf(score, 1) {
+		return WifiChannel{}, ErrNoWifiChannel
+	}
+
+	return best, nil
+}
+
+// channelOverlap returns how much of channel a is shared with channel b.
+func channelOverlap(band byte, a byte, b byte) float64 {
+	if band != ARCOMMANDS_ARDRONE3_NETWORK_WIFISCAN_BAND_2_4GHZ {
+		if a == b {
+			return 1
+		}
+		return 0
+	}
+
+	d := math.Abs(float64(a) - float64(b))
+	if d >= 5 {
+		return 0
+	}
+
+	return (5 - d) / 5
+}
+
+func (b *Bebop) decodeNetworkState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_ARDrone3NetworkState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_ARDRONE3_NETWORKSTATE_CMD_WIFISCANLISTCHANGED:
+		//
+		// string - ssid SSID of the AP
+		// int16  - rssi RSSI of the AP in dbm (negative value)
+		// eARCOMMANDS_ARDRONE3_NETWORKSTATE_WIFISCANLISTCHANGED_BAND - band
+		// uint8  - channel Channel of the AP
+		//
+		var (
+			network WifiNetwork
+			band    uint32
+		)
+		if err := decodeArgs(args, &network.SSID, &network.RSSI, &band, &network.Channel); err != nil {
+			return err
+		}
+		network.Band = byte(band)
+
+		b.stateLock.Lock()
+		b.wifiScan = append(b.wifiScan, network)
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_NETWORKSTATE_CMD_WIFIAUTHCHANNELLISTCHANGED:
+		//
+		// eARCOMMANDS_ARDRONE3_NETWORKSTATE_WIFIAUTHCHANNELLISTCHANGED_BAND - band
+		// uint8 - channel The authorized channel
+		// uint8 - in_or_out Bit 0 is 1 if channel is authorized outside,
+		//         Bit 1 is 1 if channel is authorized inside
+		//
+		var (
+			channel WifiChannel
+			band    uint32
+			inOrOut uint8
+		)
+		if err := decodeArgs(args, &band, &channel.Channel, &inOrOut); err != nil {
+			return err
+		}
+		channel.Band = byte(band)
+		channel.Outdoor = inOrOut&1 != 0
+		channel.Indoor = inOrOut&2 != 0
+
+		b.stateLock.Lock()
+		b.wifiChannels = append(b.wifiChannels, channel)
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
+
+func (b *Bebop) decodeNetworkSettingsState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_ARDrone3NetworkSettingsState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_ARDRONE3_NETWORKSETTINGSSTATE_CMD_WIFISELECTIONCHANGED:
+		//
+		// eARCOMMANDS_ARDRONE3_NETWORKSETTINGSSTATE_WIFISELECTIONCHANGED_TYPE - type
+		// eARCOMMANDS_ARDRONE3_NETWORKSETTINGSSTATE_WIFISELECTIONCHANGED_BAND - band
+		// uint8 - channel The channel (depends of the band)
+		//
+		var (
+			selection, band uint32
+			channel         uint8
+		)
+		if err := decodeArgs(args, &selection, &band, &channel); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.wifiSelection = WifiSelection{Type: byte(selection), Band: byte(band), Channel: channel}
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package client
+
+// Overheat is the overheat state of the drone.
+type Overheat struct {
+	// Overheating is true once the drone reported that it is overheating
+	Overheating bool
+	// Regulation is how the drone regulates its temperature, one of
+	// ARCOMMANDS_COMMON_OVERHEATSTATE_OVERHEATREGULATIONCHANGED_REGULATIONTYPE_*
+	Regulation byte
+}
+
+// Overheat returns the overheat state last reported by the drone.
+func (b *Bebop) Overheat() Overheat {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.overheat
+}
+
+// Overheated returns a channel receiving the overheat state every time the
+// drone reports that it is overheating. States are dropped when the channel
+// is not read. When StopVideoOnOverheat is set, the video stream is also
+// disabled if the drone overheats while landed.
+func (b *Bebop) Overheated() <-chan Overheat {
+	return b.overheated
+}
+
+// SwitchOff asks the drone to cool down by switching off.
+func (b *Bebop) SwitchOff() error {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonOverHeatSwitchOff
+	//
+
+	return b.writeWithAck(generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_OVERHEAT,
+		ARCOMMANDS_ID_COMMON_OVERHEAT_CMD_SWITCHOFF,
+	))
+}
+
+// Ventilate asks the drone to cool down with its fans.
+func (b *Bebop) Ventilate() error {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonOverHeatVentilate
+	//
+
+	return b.writeWithAck(generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_OVERHEAT,
+		ARCOMMANDS_ID_COMMON_OVERHEAT_CMD_VENTILATE,
+	))
+}
+
+func (b *Bebop) decodeOverheatState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_CommonOverHeatState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_COMMON_OVERHEATSTATE_CMD_OVERHEATCHANGED:
+		b.stateLock.Lock()
+		b.overheat.Overheating = true
+		overheat := b.overheat
+		stopVideo := b.StopVideoOnOverheat &&
+			b.state.FlyingState == ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_LANDED
+		b.stateLock.Unlock()
+
+		select {
+		case b.overheated <- overheat:
+		default:
+		}
+
+		if stopVideo {
+			// the receiver must not block on the writer
+			go b.VideoEnable(false)
+		}
+	case ARCOMMANDS_ID_COMMON_OVERHEATSTATE_CMD_OVERHEATREGULATIONCHANGED:
+		//
+		// uint8 - regulationType Type of overheat regulation : 0 for ventilation, 1 for switch off
+		//
+		var regulation uint8
+		if err := decodeArgs(args, &regulation); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.overheat.Regulation = regulation
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,426 @@
+package client
+
+import "fmt"
+
+// PictureSettings are the camera settings reported by the drone.
+type PictureSettings struct {
+	// PictureFormat is one of ARCOMMANDS_ARDRONE3_PICTURESETTINGS_PICTUREFORMATSELECTION_TYPE_*
+	PictureFormat byte
+	// AutoWhiteBalance is one of ARCOMMANDS_ARDRONE3_PICTURESETTINGS_AUTOWHITEBALANCESELECTION_TYPE_*
+	AutoWhiteBalance byte
+	// Exposition compensation in EV
+	Exposition FloatSetting
+	// Saturation from -100 to 100
+	Saturation FloatSetting
+	// Timelapse takes a picture every TimelapseInterval seconds when enabled
+	Timelapse         bool
+	TimelapseInterval FloatSetting
+	// VideoAutorecord starts recording when the drone takes off
+	VideoAutorecord bool
+	// VideoStabilization is one of ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOSTABILIZATIONMODE_MODE_*
+	VideoStabilization byte
+	// VideoRecordingMode is one of ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORECORDINGMODE_MODE_*
+	VideoRecordingMode byte
+	// VideoFramerate is one of ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOFRAMERATE_FRAMERATE_*
+	VideoFramerate byte
+	// VideoResolution is one of ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORESOLUTIONS_TYPE_*
+	VideoResolution byte
+}
+
+// checkEnum returns an error if value is not below max.
+func checkEnum(name string, value byte, max byte) error {
+	if value >= max {
+		return fmt.Errorf("%s %d is not supported", name, value)
+	}
+
+	return nil
+}
+
+// PictureSettings returns the camera settings last reported by the drone.
+func (b *Bebop) PictureSettings() PictureSettings {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.pictureSettings
+}
+
+// SetPictureSettings applies every setting of s, so a camera profile saved
+// from PictureSettings can be restored. All values are checked before
+// anything is sent to the drone.
+func (b *Bebop) SetPictureSettings(s PictureSettings) error {
+	current := b.PictureSettings()
+
+	checks := []error{
+		checkEnum("picture format", s.PictureFormat, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_PICTUREFORMATSELECTION_TYPE_MAX),
+		checkEnum("white balance", s.AutoWhiteBalance, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_AUTOWHITEBALANCESELECTION_TYPE_MAX),
+		current.Exposition.check("exposition", s.Exposition.Current),
+		current.Saturation.check("saturation", s.Saturation.Current),
+		checkEnum("video stabilization", s.VideoStabilization, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOSTABILIZATIONMODE_MODE_MAX),
+		checkEnum("video recording mode", s.VideoRecordingMode, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORECORDINGMODE_MODE_MAX),
+		checkEnum("video framerate", s.VideoFramerate, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOFRAMERATE_FRAMERATE_MAX),
+		checkEnum("video resolution", s.VideoResolution, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORESOLUTIONS_TYPE_MAX),
+	}
+	if s.Timelapse {
+		checks = append(checks, current.TimelapseInterval.check("timelapse interval", s.TimelapseInterval.Current))
+	}
+	for _, err := range checks {
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := b.SetPictureFormat(s.PictureFormat); err != nil {
+		return err
+	}
+	if _, err := b.SetAutoWhiteBalance(s.AutoWhiteBalance); err != nil {
+		return err
+	}
+	if _, err := b.SetExposition(s.Exposition.Current); err != nil {
+		return err
+	}
+	if _, err := b.SetSaturation(s.Saturation.Current); err != nil {
+		return err
+	}
+	if _, err := b.SetTimelapse(s.Timelapse, s.TimelapseInterval.Current); err != nil {
+		return err
+	}
+	if _, err := b.SetVideoAutorecord(s.VideoAutorecord); err != nil {
+		return err
+	}
+	if _, err := b.SetVideoStabilization(s.VideoStabilization); err != nil {
+		return err
+	}
+	if _, err := b.SetVideoRecordingMode(s.VideoRecordingMode); err != nil {
+		return err
+	}
+	if _, err := b.SetVideoFramerate(s.VideoFramerate); err != nil {
+		return err
+	}
+	if _, err := b.SetVideoResolution(s.VideoResolution); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetPictureFormat sets the format of the pictures taken by the drone and
+// returns the format confirmed by the drone.
+func (b *Bebop) SetPictureFormat(format byte) (byte, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PictureSettingsPictureFormatSelection
+	//
+	// eARCOMMANDS_ARDRONE3_PICTURESETTINGS_PICTUREFORMATSELECTION_TYPE - type The type of photo format
+	//
+
+	if err := checkEnum("picture format", format, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_PICTUREFORMATSELECTION_TYPE_MAX); err != nil {
+		return 0, err
+	}
+
+	settings, err := b.pictureSetting(
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_PICTUREFORMATSELECTION,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_PICTUREFORMATCHANGED,
+		uint32(format),
+	)
+	return settings.PictureFormat, err
+}
+
+// SetAutoWhiteBalance sets the white balance mode and returns the mode
+// confirmed by the drone.
+func (b *Bebop) SetAutoWhiteBalance(mode byte) (byte, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PictureSettingsAutoWhiteBalanceSelection
+	//
+	// eARCOMMANDS_ARDRONE3_PICTURESETTINGS_AUTOWHITEBALANCESELECTION_TYPE - type The type auto white balance
+	//
+
+	if err := checkEnum("white balance", mode, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_AUTOWHITEBALANCESELECTION_TYPE_MAX); err != nil {
+		return 0, err
+	}
+
+	settings, err := b.pictureSetting(
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_AUTOWHITEBALANCESELECTION,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_AUTOWHITEBALANCECHANGED,
+		uint32(mode),
+	)
+	return settings.AutoWhiteBalance, err
+}
+
+// SetExposition sets the exposition compensation and returns the value
+// confirmed by the drone.
+func (b *Bebop) SetExposition(value float32) (FloatSetting, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PictureSettingsExpositionSelection
+	//
+	// float - value Exposition value (bounds given by ExpositionChanged arg min and max, by default [-3:3])
+	//
+
+	if err := b.PictureSettings().Exposition.check("exposition", value); err != nil {
+		return FloatSetting{}, err
+	}
+
+	settings, err := b.pictureSetting(
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_EXPOSITIONSELECTION,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_EXPOSITIONCHANGED,
+		value,
+	)
+	return settings.Exposition, err
+}
+
+// SetSaturation sets the saturation and returns the value confirmed by the
+// drone.
+func (b *Bebop) SetSaturation(value float32) (FloatSetting, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PictureSettingsSaturationSelection
+	//
+	// float - value Saturation value (bounds given by SaturationChanged arg min and max, by default [-100:100])
+	//
+
+	if err := b.PictureSettings().Saturation.check("saturation", value); err != nil {
+		return FloatSetting{}, err
+	}
+
+	settings, err := b.pictureSetting(
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_SATURATIONSELECTION,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_SATURATIONCHANGED,
+		value,
+	)
+	return settings.Saturation, err
+}
+
+// SetTimelapse enables or disables timelapse with a picture every interval
+// seconds and returns the interval confirmed by the drone.
+func (b *Bebop) SetTimelapse(enabled bool, interval float32) (FloatSetting, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PictureSettingsTimelapseSelection
+	//
+	// uint8 - enabled 1 if timelapse is enabled, 0 otherwise
+	// float - interval interval in seconds for taking pictures
+	//
+
+	if enabled {
+		if err := b.PictureSettings().TimelapseInterval.check("timelapse interval", interval); err != nil {
+			return FloatSetting{}, err
+		}
+	}
+
+	settings, err := b.pictureSetting(
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_TIMELAPSESELECTION,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_TIMELAPSECHANGED,
+		enabled,
+		interval,
+	)
+	return settings.TimelapseInterval, err
+}
+
+// SetVideoAutorecord makes the drone record to its internal storage as soon
+// as it takes off and returns the value confirmed by the drone.
+func (b *Bebop) SetVideoAutorecord(enabled bool) (bool, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PictureSettingsVideoAutorecordSelection
+	//
+	// uint8 - enabled 1 if video autorecord is enabled, 0 otherwise
+	// uint8 - mass_storage_id Mass storage id to take video
+	//
+
+	settings, err := b.pictureSetting(
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_VIDEOAUTORECORDSELECTION,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEOAUTORECORDCHANGED,
+		enabled,
+		uint8(0),
+	)
+	return settings.VideoAutorecord, err
+}
+
+// SetVideoStabilization sets the video stabilization mode and returns the
+// mode confirmed by the drone.
+func (b *Bebop) SetVideoStabilization(mode byte) (byte, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PictureSettingsVideoStabilizationMode
+	//
+	// eARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOSTABILIZATIONMODE_MODE - mode Video stabilization mode
+	//
+
+	if err := checkEnum("video stabilization", mode, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOSTABILIZATIONMODE_MODE_MAX); err != nil {
+		return 0, err
+	}
+
+	settings, err := b.pictureSetting(
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_VIDEOSTABILIZATIONMODE,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEOSTABILIZATIONMODECHANGED,
+		uint32(mode),
+	)
+	return settings.VideoStabilization, err
+}
+
+// SetVideoRecordingMode chooses between best quality and longest recording
+// time and returns the mode confirmed by the drone.
+func (b *Bebop) SetVideoRecordingMode(mode byte) (byte, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PictureSettingsVideoRecordingMode
+	//
+	// eARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORECORDINGMODE_MODE - mode Video recording mode
+	//
+
+	if err := checkEnum("video recording mode", mode, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORECORDINGMODE_MODE_MAX); err != nil {
+		return 0, err
+	}
+
+	settings, err := b.pictureSetting(
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_VIDEORECORDINGMODE,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEORECORDINGMODECHANGED,
+		uint32(mode),
+	)
+	return settings.VideoRecordingMode, err
+}
+
+// SetVideoFramerate sets the video framerate and returns the framerate
+// confirmed by the drone.
+func (b *Bebop) SetVideoFramerate(framerate byte) (byte, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PictureSettingsVideoFramerate
+	//
+	// eARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOFRAMERATE_FRAMERATE - framerate Video framerate
+	//
+
+	if err := checkEnum("video framerate", framerate, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEOFRAMERATE_FRAMERATE_MAX); err != nil {
+		return 0, err
+	}
+
+	settings, err := b.pictureSetting(
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_VIDEOFRAMERATE,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEOFRAMERATECHANGED,
+		uint32(framerate),
+	)
+	return settings.VideoFramerate, err
+}
+
+// SetVideoResolution sets the recording and streaming resolutions and
+// returns the resolution confirmed by the drone.
+func (b *Bebop) SetVideoResolution(resolution byte) (byte, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PictureSettingsVideoResolutions
+	//
+	// eARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORESOLUTIONS_TYPE - type Video streaming and recording resolutions
+	//
+
+	if err := checkEnum("video resolution", resolution, ARCOMMANDS_ARDRONE3_PICTURESETTINGS_VIDEORESOLUTIONS_TYPE_MAX); err != nil {
+		return 0, err
+	}
+
+	settings, err := b.pictureSetting(
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGS_CMD_VIDEORESOLUTIONS,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEORESOLUTIONSCHANGED,
+		uint32(resolution),
+	)
+	return settings.VideoResolution, err
+}
+
+// pictureSetting sends a picture setting and waits for the drone to report
+// the resulting state.
+func (b *Bebop) pictureSetting(id byte, state byte, args ...interface{}) (PictureSettings, error) {
+	err := b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PICTURESETTINGS,
+		id,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PICTURESETTINGSSTATE,
+		state,
+		args...,
+	)
+	if err != nil {
+		return PictureSettings{}, err
+	}
+
+	return b.PictureSettings(), nil
+}
+
+func (b *Bebop) decodePictureSettingsState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_ARDrone3PictureSettingsState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_PICTUREFORMATCHANGED,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_AUTOWHITEBALANCECHANGED,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEOSTABILIZATIONMODECHANGED,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEORECORDINGMODECHANGED,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEOFRAMERATECHANGED,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEORESOLUTIONSCHANGED:
+		//
+		// eARCOMMANDS_ARDRONE3_PICTURESETTINGSSTATE_* - the selected type or mode
+		//
+		var value uint32
+		if err := decodeArgs(args, &value); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		switch cmd {
+		case ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_PICTUREFORMATCHANGED:
+			b.pictureSettings.PictureFormat = byte(value)
+		case ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_AUTOWHITEBALANCECHANGED:
+			b.pictureSettings.AutoWhiteBalance = byte(value)
+		case ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEOSTABILIZATIONMODECHANGED:
+			b.pictureSettings.VideoStabilization = byte(value)
+		case ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEORECORDINGMODECHANGED:
+			b.pictureSettings.VideoRecordingMode = byte(value)
+		case ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEOFRAMERATECHANGED:
+			b.pictureSettings.VideoFramerate = byte(value)
+		default:
+			b.pictureSettings.VideoResolution = byte(value)
+		}
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_EXPOSITIONCHANGED,
+		ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_SATURATIONCHANGED:
+		//
+		// float - value
+		// float - min
+		// float - max
+		//
+		var setting FloatSetting
+		if err := decodeArgs(args, &setting.Current, &setting.Min, &setting.Max); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		if cmd == ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_EXPOSITIONCHANGED {
+			b.pictureSettings.Exposition = setting
+		} else {
+			b.pictureSettings.Saturation = setting
+		}
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_TIMELAPSECHANGED:
+		//
+		// uint8 - enabled 1 if timelapse is enabled, 0 otherwise
+		// float - interval interval in seconds for taking pictures
+		// float - minInterval Minimal interval for taking pictures
+		// float - maxInterval Maximal interval for taking pictures
+		//
+		var (
+			enabled  uint8
+			interval FloatSetting
+		)
+		if err := decodeArgs(args, &enabled, &interval.Current, &interval.Min, &interval.Max); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.pictureSettings.Timelapse = enabled == 1
+		b.pictureSettings.TimelapseInterval = interval
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_PICTURESETTINGSSTATE_CMD_VIDEOAUTORECORDCHANGED:
+		//
+		// uint8 - enabled 1 if video autorecord is enabled, 0 otherwise
+		// uint8 - mass_storage_id Mass storage id for the taken video
+		//
+		var enabled, storage uint8
+		if err := decodeArgs(args, &enabled, &storage); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.pictureSettings.VideoAutorecord = enabled == 1
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
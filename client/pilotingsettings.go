@@ -0,0 +1,242 @@
+package client
+
+import "fmt"
+
+// FloatSetting is a setting as confirmed by the drone along with the range
+// of values the drone accepts for it.
+type FloatSetting struct {
+	Current float32
+	Min     float32
+	Max     float32
+}
+
+// reported returns true once the drone has reported the setting.
+func (s FloatSetting) reported() bool {
+	return s.Min != 0 || s.Max != 0
+}
+
+// check returns an error if value is outside of the range reported by the
+// drone. Any value is accepted until the drone has reported a range.
+func (s FloatSetting) check(name string, value float32) error {
+	if !s.reported() {
+		return nil
+	}
+
+	if value < s.Min || value > s.Max {
+		return fmt.Errorf("%s %v is out of range [%v, %v]", name, value, s.Min, s.Max)
+	}
+
+	return nil
+}
+
+// PilotingSettings are the flight limits reported by the drone.
+type PilotingSettings struct {
+	// MaxAltitude in meters
+	MaxAltitude FloatSetting
+	// MaxTilt in degrees
+	MaxTilt FloatSetting
+	// MaxDistance from the home position in meters
+	MaxDistance FloatSetting
+	// AbsolutControl makes the drone use the controllers orientation
+	AbsolutControl bool
+	// NoFlyOverMaxDistance keeps the drone from flying further than MaxDistance
+	NoFlyOverMaxDistance bool
+	// BankedTurn couples yaw and roll for smoother turns
+	BankedTurn bool
+}
+
+// PilotingSettings returns the piloting settings last reported by the drone.
+func (b *Bebop) PilotingSettings() PilotingSettings {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.pilotingSettings
+}
+
+// SetMaxAltitude sets the maximum altitude in meters and returns the value
+// confirmed by the drone.
+func (b *Bebop) SetMaxAltitude(altitude float32) (FloatSetting, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PilotingSettingsMaxAltitude
+	//
+	// float - current Current altitude max in m
+	//
+
+	if err := b.PilotingSettings().MaxAltitude.check("max altitude", altitude); err != nil {
+		return FloatSetting{}, err
+	}
+
+	settings, err := b.pilotingSetting(
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD_MAXALTITUDE,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_MAXALTITUDECHANGED,
+		altitude,
+	)
+	return settings.MaxAltitude, err
+}
+
+// SetMaxTilt sets the maximum tilt in degrees and returns the value
+// confirmed by the drone.
+func (b *Bebop) SetMaxTilt(tilt float32) (FloatSetting, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PilotingSettingsMaxTilt
+	//
+	// float - current Current tilt max in degree
+	//
+
+	if err := b.PilotingSettings().MaxTilt.check("max tilt", tilt); err != nil {
+		return FloatSetting{}, err
+	}
+
+	settings, err := b.pilotingSetting(
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD_MAXTILT,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_MAXTILTCHANGED,
+		tilt,
+	)
+	return settings.MaxTilt, err
+}
+
+// SetMaxDistance sets the maximum distance from the home position in meters
+// and returns the value confirmed by the drone. The distance is only
+// enforced when NoFlyOverMaxDistance is enabled.
+func (b *Bebop) SetMaxDistance(distance float32) (FloatSetting, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PilotingSettingsMaxDistance
+	//
+	// float - value Current max distance in meter
+	//
+
+	if err := b.PilotingSettings().MaxDistance.check("max distance", distance); err != nil {
+		return FloatSetting{}, err
+	}
+
+	settings, err := b.pilotingSetting(
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD_MAXDISTANCE,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_MAXDISTANCECHANGED,
+		distance,
+	)
+	return settings.MaxDistance, err
+}
+
+// SetAbsolutControl enables or disables absolute control and returns the
+// value confirmed by the drone.
+func (b *Bebop) SetAbsolutControl(on bool) (bool, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PilotingSettingsAbsolutControl
+	//
+	// uint8 - on 1 to enable, 0 to disable
+	//
+
+	settings, err := b.pilotingSetting(
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD_ABSOLUTCONTROL,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_ABSOLUTCONTROLCHANGED,
+		on,
+	)
+	return settings.AbsolutControl, err
+}
+
+// SetNoFlyOverMaxDistance keeps the drone within MaxDistance of its home
+// position and returns the value confirmed by the drone.
+func (b *Bebop) SetNoFlyOverMaxDistance(on bool) (bool, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PilotingSettingsNoFlyOverMaxDistance
+	//
+	// uint8 - shouldNotFlyOver 1 if the drone can't fly further than max distance
+	//
+
+	settings, err := b.pilotingSetting(
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD_NOFLYOVERMAXDISTANCE,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_NOFLYOVERMAXDISTANCECHANGED,
+		on,
+	)
+	return settings.NoFlyOverMaxDistance, err
+}
+
+// SetBankedTurn enables or disables banked turns and returns the value
+// confirmed by the drone.
+func (b *Bebop) SetBankedTurn(on bool) (bool, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3PilotingSettingsBankedTurn
+	//
+	// uint8 - value 1 to enable, 0 to disable
+	//
+
+	settings, err := b.pilotingSetting(
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGS_CMD_BANKEDTURN,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_BANKEDTURNCHANGED,
+		on,
+	)
+	return settings.BankedTurn, err
+}
+
+// pilotingSetting sends a piloting setting and waits for the drone to
+// report the resulting state.
+func (b *Bebop) pilotingSetting(id byte, state byte, value interface{}) (PilotingSettings, error) {
+	err := b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSETTINGS,
+		id,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_PILOTINGSETTINGSSTATE,
+		state,
+		value,
+	)
+	if err != nil {
+		return PilotingSettings{}, err
+	}
+
+	return b.PilotingSettings(), nil
+}
+
+func (b *Bebop) decodePilotingSettingsState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_ARDrone3PilotingSettingsState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_MAXALTITUDECHANGED,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_MAXTILTCHANGED,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_MAXDISTANCECHANGED:
+		//
+		// float - current
+		// float - min Range min
+		// float - max Range max
+		//
+		var setting FloatSetting
+		if err := decodeArgs(args, &setting.Current, &setting.Min, &setting.Max); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		switch cmd {
+		case ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_MAXALTITUDECHANGED:
+			b.pilotingSettings.MaxAltitude = setting
+		case ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_MAXTILTCHANGED:
+			b.pilotingSettings.MaxTilt = setting
+		default:
+			b.pilotingSettings.MaxDistance = setting
+		}
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_ABSOLUTCONTROLCHANGED,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_NOFLYOVERMAXDISTANCECHANGED,
+		ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_BANKEDTURNCHANGED:
+		//
+		// uint8 - 1 if enabled, 0 if disabled
+		//
+		var on uint8
+		if err := decodeArgs(args, &on); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		switch cmd {
+		case ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_ABSOLUTCONTROLCHANGED:
+			b.pilotingSettings.AbsolutControl = on == 1
+		case ARCOMMANDS_ID_ARDRONE3_PILOTINGSETTINGSSTATE_CMD_NOFLYOVERMAXDISTANCECHANGED:
+			b.pilotingSettings.NoFlyOverMaxDistance = on == 1
+		default:
+			b.pilotingSettings.BankedTurn = on == 1
+		}
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// flyingStateTimeout is how long TakeOffAndWait and LandAndWait wait for the
+// drone to react before sending the command again.
+const flyingStateTimeout = time.Second
+
+// ErrEmergency is returned when the drone enters the emergency state while
+// waiting for another flying state.
+var ErrEmergency = errors.New("drone is in emergency")
+
+// FlyingState returns the flying state last reported by the drone, one of
+// ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_*.
+func (b *Bebop) FlyingState() byte {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.state.FlyingState
+}
+
+// WaitFlyingState blocks until the drone reports one of states, or ctx is
+// done, and returns the flying state reached.
+func (b *Bebop) WaitFlyingState(ctx context.Context, states ...byte) (byte, error) {
+	var state byte
+	err := b.waitFor(ctx, func() bool {
+		state = b.state.FlyingState
+		for _, s := range states {
+			if state == s {
+				return true
+			}
+		}
+		return false
+	})
+	return state, err
+}
+
+// TakeOffAndWait makes the drone take off and blocks until it hovers, or ctx
+// is done. The command is sent again while the drone does not start taking
+// off.
+func (b *Bebop) TakeOffAndWait(ctx context.Context) error {
+	return b.commandAndWait(ctx, b.TakeOff,
+		ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_TAKINGOFF,
+		ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_HOVERING,
+		ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_FLYING,
+	)
+}
+
+// LandAndWait makes the drone land and blocks until it is landed, or ctx is
+// done. The command is sent again while the drone does not start landing.
+func (b *Bebop) LandAndWait(ctx context.Context) error {
+	return b.commandAndWait(ctx, b.Land,
+		ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_LANDING,
+		ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_LANDED,
+	)
+}
+
+// commandAndWait sends command until the drone reports the transitional
+// flying state pending or one of states, then waits for one of states.
+func (b *Bebop) commandAndWait(ctx context.Context, command func() error, pending byte, states ...byte) error {
+	final := append([]byte{ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_EMERGENCY}, states...)
+
+	for {
+		if err := command(); err != nil {
+			return err
+		}
+
+		attempt, cancel := context.WithTimeout(ctx, flyingStateTimeout)
+		_, err := b.WaitFlyingState(attempt, append(final, pending)...)
+		cancel()
+
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	state, err := b.WaitFlyingState(ctx, final...)
+	if err != nil {
+		return err
+	}
+	if state == ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_EMERGENCY {
+		return ErrEmergency
+	}
+
+	return nil
+}
+
+func (b *Bebop) decodePilotingState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_ARDrone3PilotingState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_FLYINGSTATECHANGED:
+		//
+		// eARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE - state Drone flying state
+		//
+		var state uint32
+		if err := decodeArgs(args, &state); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.updateState(StateFlyingState, func(s *State) { s.FlyingState = byte(state) })
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_ALERTSTATECHANGED:
+		//
+		// eARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE - state Drone alert state
+		//
+		var alert uint32
+		if err := decodeArgs(args, &alert); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		raised := b.state.Alert != byte(alert)
+		b.updateState(StateAlert, func(s *State) { s.Alert = byte(alert) })
+		if raised {
+			b.raiseAlert(byte(alert))
+		}
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_POSITIONCHANGED:
+		//
+		// double - latitude Latitude position in decimal degrees (500.0 if not available)
+		// double - longitude Longitude position in decimal degrees (500.0 if not available)
+		// double - altitude Altitude in meters (from GPS)
+		//
+		var position Location
+		if err := decodeArgs(args, &position.Latitude, &position.Longitude, &position.Altitude); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.updateState(StatePosition, func(s *State) { s.Position = position })
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_SPEEDCHANGED:
+		//
+		// float - speedX Speed relative to the North (when drone moves to the north, speed is > 0) (in m/s)
+		// float - speedY Speed relative to the East (when drone moves to the east, speed is > 0) (in m/s)
+		// float - speedZ Speed on the z axis (when drone moves down, speed is > 0) (in m/s)
+		//
+		var speed Speed
+		if err := decodeArgs(args, &speed.X, &speed.Y, &speed.Z); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.updateState(StateSpeed, func(s *State) { s.Speed = speed })
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_ATTITUDECHANGED:
+		//
+		// float - roll roll value (in radian)
+		// float - pitch Pitch value (in radian)
+		// float - yaw Yaw value (in radian)
+		//
+		var attitude Attitude
+		if err := decodeArgs(args, &attitude.Roll, &attitude.Pitch, &attitude.Yaw); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.updateState(StateAttitude, func(s *State) { s.Attitude = attitude })
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_PILOTINGSTATE_CMD_ALTITUDECHANGED:
+		//
+		// double - altitude Altitude in meters
+		//
+		var altitude float64
+		if err := decodeArgs(args, &altitude); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.updateState(StateAltitude, func(s *State) { s.Altitude = altitude })
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package client
+
+// Product identifies the drone, it is reported by the drone after
+// GenerateAllSettings and GenerateAllStates.
+type Product struct {
+	Name            string
+	SoftwareVersion string
+	HardwareVersion string
+	// SerialHigh and SerialLow are the two halves of the serial number
+	SerialHigh string
+	SerialLow  string
+	// LibARCommandsVersion is the version of the ARCommands library of the
+	// drone
+	LibARCommandsVersion string
+	// ControllerLibARCommandsVersion and SkyControllerLibARCommandsVersion
+	// are the versions of the ARCommands library of the controllers the
+	// drone knows about
+	ControllerLibARCommandsVersion    string
+	SkyControllerLibARCommandsVersion string
+}
+
+// Serial returns the serial number of the drone.
+func (p Product) Serial() string {
+	return p.SerialHigh + p.SerialLow
+}
+
+// Product returns the identity of the drone last reported by the drone.
+func (b *Bebop) Product() Product {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.product
+}
+
+func (b *Bebop) decodeARLibsVersionsState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_CommonARLibsVersionsState*
+	//
+	// string - version version of libARCommands ("1.2.3.4" format)
+	//
+
+	var version string
+	if err := decodeArgs(args, &version); err != nil {
+		return err
+	}
+
+	b.stateLock.Lock()
+	defer b.stateLock.Unlock()
+
+	switch cmd {
+	case ARCOMMANDS_ID_COMMON_ARLIBSVERSIONSSTATE_CMD_CONTROLLERLIBARCOMMANDSVERSION:
+		b.product.ControllerLibARCommandsVersion = version
+	case ARCOMMANDS_ID_COMMON_ARLIBSVERSIONSSTATE_CMD_SKYCONTROLLERLIBARCOMMANDSVERSION:
+		b.product.SkyControllerLibARCommandsVersion = version
+	case ARCOMMANDS_ID_COMMON_ARLIBSVERSIONSSTATE_CMD_DEVICELIBARCOMMANDSVERSION:
+		b.product.LibARCommandsVersion = version
+	}
+
+	return nil
+}
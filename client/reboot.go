@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// reconnectInterval is how often the drone is polled while it reboots
+	reconnectInterval = 250 * time.Millisecond
+	// discoveryTimeout is how long a discovery attempt may take, the Wi-Fi
+	// network of the drone disappears while it reboots
+	discoveryTimeout = 2 * time.Second
+	// offlineDials is how many discovery attempts in a row must fail before
+	// the drone is taken as down, a single one may be a Wi-Fi hiccup
+	offlineDials = 3
+)
+
+// ErrNotLanded is returned by commands which are refused while the drone
+// is not landed.
+var ErrNotLanded = errors.New("drone is not landed")
+
+// Reboot restarts the drone and blocks until it is connected again and has
+// sent all its states, or ctx is done. It is refused with ErrNotLanded while
+// the drone is not landed.
+func (b *Bebop) Reboot(ctx context.Context) error {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonCommonReboot
+	//
+
+	if b.FlyingState() != ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_LANDED {
+		return ErrNotLanded
+	}
+
+	err := b.writeWithAck(generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_COMMON,
+		ARCOMMANDS_ID_COMMON_COMMON_CMD_REBOOT,
+	))
+	if err != nil {
+		return err
+	}
+
+	if err := b.waitOffline(ctx); err != nil {
+		return err
+	}
+
+	return b.reconnect(ctx)
+}
+
+// waitOffline blocks until the discovery service of the drone stops
+// answering offlineDials times in a row, which tells that the drone went
+// down.
+func (b *Bebop) waitOffline(ctx context.Context) error {
+	failed := 0
+	for {
+		attempt, cancel := context.WithTimeout(ctx, discoveryTimeout)
+		conn, err := b.dialDiscovery(attempt)
+		cancel()
+		if err == nil {
+			conn.Close()
+			failed = 0
+		} else if failed++; failed >= offlineDials {
+			// the drone is down, unless ctx is done
+			return ctx.Err()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(reconnectInterval):
+		}
+	}
+}
+
+// reconnect runs the discovery until the drone answers, then asks for all
+// its states and waits for them.
+func (b *Bebop) reconnect(ctx context.Context) error {
+	for {
+		attempt, cancel := context.WithTimeout(ctx, discoveryTimeout)
+		err := b.discover(attempt)
+		cancel()
+		if err == nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(reconnectInterval):
+		}
+	}
+
+	//
+	// ARCOMMANDS_Generator_GenerateCommonCommonAllStates
+	//
+
+	cmd := generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_COMMON,
+		ARCOMMANDS_ID_COMMON_COMMON_CMD_ALLSTATES,
+	)
+
+	// the drone may not listen yet right after the discovery
+	for {
+		err := b.writeWithAckAndWait(ctx, cmd,
+			ARCOMMANDS_ID_PROJECT_COMMON,
+			ARCOMMANDS_ID_COMMON_CLASS_COMMONSTATE,
+			ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_ALLSTATESCHANGED,
+		)
+		if err != ErrNoAck {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
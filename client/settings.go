@@ -0,0 +1,273 @@
+package client
+
+import "context"
+
+// Settings are all the settings of the drone.
+type Settings struct {
+	Product Product
+	// Country is the ISO 3166 code of the country the drone is in
+	Country string
+	// AutoCountry is true when the drone picks its country automatically
+	AutoCountry bool
+	// WifiOutdoor is true when the Wi-Fi uses the outdoor channels
+	WifiOutdoor    bool
+	Piloting       PilotingSettings
+	Speed          SpeedSettings
+	WifiSelection  WifiSelection
+	Picture        PictureSettings
+	GPS            GPSSettings
+	Antiflickering Antiflickering
+}
+
+// AllSettings asks the drone for all its settings and blocks until it has
+// sent all of them or ctx is done.
+func (b *Bebop) AllSettings(ctx context.Context) (Settings, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonSettingsAllSettings
+	//
+
+	cmd := generateCommand(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_SETTINGS,
+		ARCOMMANDS_ID_COMMON_SETTINGS_CMD_ALLSETTINGS,
+	)
+
+	err := b.writeWithAckAndWait(ctx, cmd,
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE,
+		ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_ALLSETTINGSCHANGED,
+	)
+	if err != nil {
+		return Settings{}, err
+	}
+
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return Settings{
+		Product:        b.product,
+		Country:        b.country,
+		AutoCountry:    b.autoCountry,
+		WifiOutdoor:    b.wifiOutdoor,
+		Piloting:       b.pilotingSettings,
+		Speed:          b.speedSettings,
+		WifiSelection:  b.wifiSelection,
+		Picture:        b.pictureSettings,
+		GPS:            b.gpsSettings,
+		Antiflickering: b.antiflickering,
+	}, nil
+}
+
+// SettingsReset restores the factory settings of the drone.
+func (b *Bebop) SettingsReset() error {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonSettingsReset
+	//
+
+	return b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_SETTINGS,
+		ARCOMMANDS_ID_COMMON_SETTINGS_CMD_RESET,
+		ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE,
+		ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_RESETCHANGED,
+	)
+}
+
+// SetProductName renames the drone, which also renames its Wi-Fi network
+// after the drone restarts, and returns the name confirmed by the drone.
+func (b *Bebop) SetProductName(name string) (string, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonSettingsProductName
+	//
+	// string - name Product name
+	//
+
+	err := b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_SETTINGS,
+		ARCOMMANDS_ID_COMMON_SETTINGS_CMD_PRODUCTNAME,
+		ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE,
+		ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTNAMECHANGED,
+		name,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return b.Product().Name, nil
+}
+
+// SetCountry sets the ISO 3166 code of the country the drone is in, which
+// decides the Wi-Fi channels it may use, and returns the country confirmed
+// by the drone.
+func (b *Bebop) SetCountry(code string) (string, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonSettingsCountry
+	//
+	// string - code Country code with ISO 3166 format
+	//
+
+	err := b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_SETTINGS,
+		ARCOMMANDS_ID_COMMON_SETTINGS_CMD_COUNTRY,
+		ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE,
+		ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_COUNTRYCHANGED,
+		code,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.country, nil
+}
+
+// AutoCountry makes the drone pick its country automatically and returns
+// the value confirmed by the drone.
+func (b *Bebop) AutoCountry(automatic bool) (bool, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonSettingsAutoCountry
+	//
+	// uint8 - automatic Boolean : 0 : Manual / 1 : Auto
+	//
+
+	err := b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_SETTINGS,
+		ARCOMMANDS_ID_COMMON_SETTINGS_CMD_AUTOCOUNTRY,
+		ARCOMMANDS_ID_COMMON_CLASS_SETTINGSSTATE,
+		ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_AUTOCOUNTRYCHANGED,
+		automatic,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.autoCountry, nil
+}
+
+// WifiOutdoor makes the Wi-Fi use the channels authorized outdoor and
+// returns the value confirmed by the drone.
+func (b *Bebop) WifiOutdoor(outdoor bool) (bool, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateCommonWifiSettingsOutdoorSetting
+	//
+	// uint8 - outdoor 1 if it should use outdoor wifi settings, 0 otherwise
+	//
+
+	err := b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_COMMON,
+		ARCOMMANDS_ID_COMMON_CLASS_WIFISETTINGS,
+		ARCOMMANDS_ID_COMMON_WIFISETTINGS_CMD_OUTDOORSETTING,
+		ARCOMMANDS_ID_COMMON_CLASS_WIFISETTINGSSTATE,
+		ARCOMMANDS_ID_COMMON_WIFISETTINGSSTATE_CMD_OUTDOORSETTINGSCHANGED,
+		outdoor,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.wifiOutdoor, nil
+}
+
+func (b *Bebop) decodeSettingsState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_CommonSettingsState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTNAMECHANGED,
+		ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTSERIALHIGHCHANGED,
+		ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTSERIALLOWCHANGED:
+		//
+		// string - name, high or low
+		//
+		var value string
+		if err := decodeArgs(args, &value); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		switch cmd {
+		case ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTNAMECHANGED:
+			b.product.Name = value
+		case ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTSERIALHIGHCHANGED:
+			b.product.SerialHigh = value
+		default:
+			b.product.SerialLow = value
+		}
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_PRODUCTVERSIONCHANGED:
+		//
+		// string - software Product software version
+		// string - hardware Product hardware version
+		//
+		var software, hardware string
+		if err := decodeArgs(args, &software, &hardware); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.product.SoftwareVersion = software
+		b.product.HardwareVersion = hardware
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_COUNTRYCHANGED:
+		//
+		// string - code Country code with ISO 3166 format, empty string means unknown country.
+		//
+		var code string
+		if err := decodeArgs(args, &code); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.country = code
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_COMMON_SETTINGSSTATE_CMD_AUTOCOUNTRYCHANGED:
+		//
+		// uint8 - automatic Boolean : 0 : Manual / 1 : Auto
+		//
+		var automatic uint8
+		if err := decodeArgs(args, &automatic); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.autoCountry = automatic == 1
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
+
+func (b *Bebop) decodeWifiSettingsState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_CommonWifiSettingsState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_COMMON_WIFISETTINGSSTATE_CMD_OUTDOORSETTINGSCHANGED:
+		//
+		// uint8 - outdoor 1 if it should use outdoor wifi settings, 0 otherwise
+		//
+		var outdoor uint8
+		if err := decodeArgs(args, &outdoor); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.wifiOutdoor = outdoor == 1
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
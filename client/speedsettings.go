@@ -0,0 +1,239 @@
+package client
+
+import "fmt"
+
+// SpeedSettings are the speed limits reported by the drone.
+type SpeedSettings struct {
+	// MaxVerticalSpeed in m/s
+	MaxVerticalSpeed FloatSetting
+	// MaxRotationSpeed around the yaw axis in degrees/s
+	MaxRotationSpeed FloatSetting
+	// MaxPitchRollRotationSpeed in degrees/s
+	MaxPitchRollRotationSpeed FloatSetting
+	// HullProtection is true if the hull/prop protectors are attached
+	HullProtection bool
+	// Outdoor is true if the drone is setup for flying outdoor
+	Outdoor bool
+}
+
+// SpeedProfile groups the settings that define how aggressively the drone
+// flies, so they can be switched together.
+type SpeedProfile struct {
+	// MaxTilt in degrees
+	MaxTilt float32
+	// MaxVerticalSpeed in m/s
+	MaxVerticalSpeed float32
+	// MaxRotationSpeed around the yaw axis in degrees/s
+	MaxRotationSpeed float32
+	// MaxPitchRollRotationSpeed in degrees/s
+	MaxPitchRollRotationSpeed float32
+}
+
+// SpeedSettings returns the speed settings last reported by the drone.
+func (b *Bebop) SpeedSettings() SpeedSettings {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.speedSettings
+}
+
+// SetMaxVerticalSpeed sets the maximum vertical speed in m/s and returns
+// the value confirmed by the drone.
+func (b *Bebop) SetMaxVerticalSpeed(speed float32) (FloatSetting, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3SpeedSettingsMaxVerticalSpeed
+	//
+	// float - current Current max vertical speed in m/s
+	//
+
+	if err := b.SpeedSettings().MaxVerticalSpeed.check("max vertical speed", speed); err != nil {
+		return FloatSetting{}, err
+	}
+
+	settings, err := b.speedSetting(
+		ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGS_CMD_MAXVERTICALSPEED,
+		ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_MAXVERTICALSPEEDCHANGED,
+		speed,
+	)
+	return settings.MaxVerticalSpeed, err
+}
+
+// SetMaxRotationSpeed sets the maximum yaw rotation speed in degrees/s and
+// returns the value confirmed by the drone.
+func (b *Bebop) SetMaxRotationSpeed(speed float32) (FloatSetting, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3SpeedSettingsMaxRotationSpeed
+	//
+	// float - current Current max yaw rotation speed in degree/s
+	//
+
+	if err := b.SpeedSettings().MaxRotationSpeed.check("max rotation speed", speed); err != nil {
+		return FloatSetting{}, err
+	}
+
+	settings, err := b.speedSetting(
+		ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGS_CMD_MAXROTATIONSPEED,
+		ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_MAXROTATIONSPEEDCHANGED,
+		speed,
+	)
+	return settings.MaxRotationSpeed, err
+}
+
+// SetMaxPitchRollRotationSpeed sets the maximum pitch and roll rotation speed
+// in degrees/s and returns the value confirmed by the drone.
+func (b *Bebop) SetMaxPitchRollRotationSpeed(speed float32) (FloatSetting, error) {
+	//
+	// ARCOMMANDS_Generator_GenerateARDrone3SpeedSettingsMaxPitchRollRotationSpeed
+	//
+	// float - current Current max pitch/roll rotation speed in degree/s
+	//
+
+	if err := b.SpeedSettings().MaxPitchRollRotationSpeed.check("max pitch/roll rotation speed", speed); err != nil {
+		return FloatSetting{}, err
+	}
+
+	settings, err := b.speedSetting(
+		ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGS_CMD_MAXPITCHROLLROTATIONSPEED,
+		ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_MAXPITCHROLLROTATIONSPEEDCHANGED,
+		speed,
+	)
+	return settings.MaxPitchRollRotationSpeed, err
+}
+
+// SpeedProfile returns the current speed profile of the drone.
+func (b *Bebop) SpeedProfile() SpeedProfile {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return SpeedProfile{
+		MaxTilt:                   b.pilotingSettings.MaxTilt.Current,
+		MaxVerticalSpeed:          b.speedSettings.MaxVerticalSpeed.Current,
+		MaxRotationSpeed:          b.speedSettings.MaxRotationSpeed.Current,
+		MaxPitchRollRotationSpeed: b.speedSettings.MaxPitchRollRotationSpeed.Current,
+	}
+}
+
+// SetSpeedProfile applies all settings of p. Every value is checked against
+// the range reported by the drone before anything is sent, and if the drone
+// fails to confirm one of them the previous profile is restored. Nothing is
+// restored until the drone has reported its settings.
+func (b *Bebop) SetSpeedProfile(p SpeedProfile) error {
+	b.speedProfileLock.Lock()
+	defer b.speedProfileLock.Unlock()
+
+	piloting, speed := b.PilotingSettings(), b.SpeedSettings()
+
+	checks := []error{
+		piloting.MaxTilt.check("max tilt", p.MaxTilt),
+		speed.MaxVerticalSpeed.check("max vertical speed", p.MaxVerticalSpeed),
+		speed.MaxRotationSpeed.check("max rotation speed", p.MaxRotationSpeed),
+		speed.MaxPitchRollRotationSpeed.check("max pitch/roll rotation speed", p.MaxPitchRollRotationSpeed),
+	}
+	for _, err := range checks {
+		if err != nil {
+			return err
+		}
+	}
+
+	previous := b.SpeedProfile()
+	reported := piloting.MaxTilt.reported() &&
+		speed.MaxVerticalSpeed.reported() &&
+		speed.MaxRotationSpeed.reported() &&
+		speed.MaxPitchRollRotationSpeed.reported()
+
+	err := b.applySpeedProfile(p)
+	if err == nil || !reported {
+		return err
+	}
+
+	if rollbackErr := b.applySpeedProfile(previous); rollbackErr != nil {
+		return fmt.Errorf("%w, restoring the previous speed profile failed: %v", err, rollbackErr)
+	}
+	return err
+}
+
+func (b *Bebop) applySpeedProfile(p SpeedProfile) error {
+	if _, err := b.SetMaxTilt(p.MaxTilt); err != nil {
+		return err
+	}
+	if _, err := b.SetMaxVerticalSpeed(p.MaxVerticalSpeed); err != nil {
+		return err
+	}
+	if _, err := b.SetMaxRotationSpeed(p.MaxRotationSpeed); err != nil {
+		return err
+	}
+	if _, err := b.SetMaxPitchRollRotationSpeed(p.MaxPitchRollRotationSpeed); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// speedSetting sends a speed setting and waits for the drone to report the
+// resulting state.
+func (b *Bebop) speedSetting(id byte, state byte, value interface{}) (SpeedSettings, error) {
+	err := b.writeSetting(
+		ARCOMMANDS_ID_PROJECT_ARDRONE3,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_SPEEDSETTINGS,
+		id,
+		ARCOMMANDS_ID_ARDRONE3_CLASS_SPEEDSETTINGSSTATE,
+		state,
+		value,
+	)
+	if err != nil {
+		return SpeedSettings{}, err
+	}
+
+	return b.SpeedSettings(), nil
+}
+
+func (b *Bebop) decodeSpeedSettingsState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_ARDrone3SpeedSettingsState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_MAXVERTICALSPEEDCHANGED,
+		ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_MAXROTATIONSPEEDCHANGED,
+		ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_MAXPITCHROLLROTATIONSPEEDCHANGED:
+		//
+		// float - current
+		// float - min Range min
+		// float - max Range max
+		//
+		var setting FloatSetting
+		if err := decodeArgs(args, &setting.Current, &setting.Min, &setting.Max); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		switch cmd {
+		case ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_MAXVERTICALSPEEDCHANGED:
+			b.speedSettings.MaxVerticalSpeed = setting
+		case ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_MAXROTATIONSPEEDCHANGED:
+			b.speedSettings.MaxRotationSpeed = setting
+		default:
+			b.speedSettings.MaxPitchRollRotationSpeed = setting
+		}
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_HULLPROTECTIONCHANGED,
+		ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_OUTDOORCHANGED:
+		//
+		// uint8 - 1 if enabled, 0 if disabled
+		//
+		var on uint8
+		if err := decodeArgs(args, &on); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		if cmd == ARCOMMANDS_ID_ARDRONE3_SPEEDSETTINGSSTATE_CMD_HULLPROTECTIONCHANGED {
+			b.speedSettings.HullProtection = on == 1
+		} else {
+			b.speedSettings.Outdoor = on == 1
+		}
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package client
+
+import "time"
+
+// StateFields is a set of fields of State.
+type StateFields uint16
+
+// Fields of State.
+const (
+	StateFlyingState StateFields = 1 << iota
+	StateBattery
+	StateGPSFixed
+	StatePosition
+	StateAttitude
+	StateSpeed
+	StateAltitude
+	StateRSSI
+	StateAlert
+	StateRecording
+	StateConnected
+)
+
+// Has returns true if all the fields of field are in f.
+func (f StateFields) Has(field StateFields) bool {
+	return f&field == field
+}
+
+// Attitude of the drone in radians.
+type Attitude struct {
+	Roll  float32
+	Pitch float32
+	Yaw   float32
+}
+
+// Speed of the drone in m/s, X is to the North, Y to the East and Z to the
+// ground.
+type Speed struct {
+	X float32
+	Y float32
+	Z float32
+}
+
+// StateTimes holds when each field of State was last reported by the drone,
+// a zero time means never.
+type StateTimes struct {
+	FlyingState time.Time
+	Battery     time.Time
+	GPSFixed    time.Time
+	Position    time.Time
+	Attitude    time.Time
+	Speed       time.Time
+	Altitude    time.Time
+	RSSI        time.Time
+	Alert       time.Time
+	Recording   time.Time
+	Connected   time.Time
+}
+
+// State is the telemetry of the drone. It is a copy that is safe to keep
+// and share between goroutines.
+type State struct {
+	// FlyingState is one of ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_*
+	FlyingState byte
+	// Battery is the charge left in percent
+	Battery uint8
+	// GPSFixed is true when the drone has a GPS fix
+	GPSFixed bool
+	// Position of the drone, the drone reports 500 when it is unknown
+	Position Location
+	Attitude Attitude
+	Speed    Speed
+	// Altitude above the takeoff point in meters
+	Altitude float64
+	// RSSI of the Wi-Fi signal in dBm
+	RSSI int16
+	// Alert is one of ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_*
+	Alert byte
+	// Recording is true while the drone records a video
+	Recording bool
+	// Connected is true while the drone sends frames, its update time is
+	// when the connection was established or lost
+	Connected bool
+	Updated   StateTimes
+}
+
+// diff returns the fields whose value differs between s and o.
+func (s State) diff(o State) StateFields {
+	var changed StateFields
+	if s.FlyingState != o.FlyingState {
+		changed |= StateFlyingState
+	}
+	if s.Battery != o.Battery {
+		changed |= StateBattery
+	}
+	if s.GPSFixed != o.GPSFixed {
+		changed |= StateGPSFixed
+	}
+	if s.Position != o.Position {
+		changed |= StatePosition
+	}
+	if s.Attitude != o.Attitude {
+		changed |= StateAttitude
+	}
+	if s.Speed != o.Speed {
+		changed |= StateSpeed
+	}
+	if s.Altitude != o.Altitude {
+		changed |= StateAltitude
+	}
+	if s.RSSI != o.RSSI {
+		changed |= StateRSSI
+	}
+	if s.Alert != o.Alert {
+		changed |= StateAlert
+	}
+	if s.Recording != o.Recording {
+		changed |= StateRecording
+	}
+	if s.Connected != o.Connected {
+		changed |= StateConnected
+	}
+	return changed
+}
+
+// touch sets the update time of fields to now.
+func (t *StateTimes) touch(fields StateFields, now time.Time) {
+	for field, updated := range map[StateFields]*time.Time{
+		StateFlyingState: &t.FlyingState,
+		StateBattery:     &t.Battery,
+		StateGPSFixed:    &t.GPSFixed,
+		StatePosition:    &t.Position,
+		StateAttitude:    &t.Attitude,
+		StateSpeed:       &t.Speed,
+		StateAltitude:    &t.Altitude,
+		StateRSSI:        &t.RSSI,
+		StateAlert:       &t.Alert,
+		StateRecording:   &t.Recording,
+		StateConnected:   &t.Connected,
+	} {
+		if fields.Has(field) {
+			*updated = now
+		}
+	}
+}
+
+// StateChange is sent to subscribers when the state changes.
+type StateChange struct {
+	// State is the state after the change
+	State State
+	// Changed are the fields whose value changed
+	Changed StateFields
+}
+
+const (
+	// stateBuffer is how many changes a subscriber may lag behind.
+	stateBuffer = 16
+	// sampleBuffer is how many samples a subscriber may lag behind before
+	// samples are dropped.
+	sampleBuffer = 256
+)
+
+// State returns the telemetry last reported by the drone.
+func (b *Bebop) State() State {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.state
+}
+
+// Subscribe returns a channel receiving the state every time a value of it
+// changes, and a function to unsubscribe which closes the channel. When the
+// subscriber lags behind, the oldest changes are merged into the newer ones
+// so no changed field is missed.
+func (b *Bebop) Subscribe() (<-chan StateChange, func()) {
+	c := make(chan StateChange, stateBuffer)
+
+	b.stateLock.Lock()
+	b.subscribers[c] = struct{}{}
+	b.stateLock.Unlock()
+
+	return c, func() {
+		b.stateLock.Lock()
+		defer b.stateLock.Unlock()
+
+		if _, ok := b.subscribers[c]; ok {
+			delete(b.subscribers, c)
+			close(c)
+		}
+	}
+}
+
+// SubscribeSamples returns a channel receiving the state every time the
+// drone reports telemetry, even when no value changed, and a function to
+// unsubscribe which closes the channel. Samples are dropped when the
+// subscriber lags behind by more than sampleBuffer of them.
+func (b *Bebop) SubscribeSamples() (<-chan State, func()) {
+	c := make(chan State, sampleBuffer)
+
+	b.stateLock.Lock()
+	b.sampleSubscribers[c] = struct{}{}
+	b.stateLock.Unlock()
+
+	return c, func() {
+		b.stateLock.Lock()
+		defer b.stateLock.Unlock()
+
+		if _, ok := b.sampleSubscribers[c]; ok {
+			delete(b.sampleSubscribers, c)
+			close(c)
+		}
+	}
+}
+
+// updateState applies update to the state, records that fields were
+// reported, sends the sample to the sample subscribers and notifies the
+// subscribers of the values that changed. It must be called with the
+// stateLock held.
+func (b *Bebop) updateState(fields StateFields, update func(s *State)) {
+	previous := b.state
+	update(&b.state)
+	b.state.Updated.touch(fields, time.Now())
+
+	for c := range b.sampleSubscribers {
+		select {
+		case c <- b.state:
+		default:
+		}
+	}
+
+	changed := b.state.diff(previous)
+	if changed == 0 {
+		return
+	}
+
+	for c := range b.subscribers {
+		change := StateChange{State: b.state, Changed: changed}
+
+		select {
+		case c <- change:
+			continue
+		default:
+		}
+
+		select {
+		case old := <-c:
+			change.Changed |= old.Changed
+		default:
+		}
+
+		select {
+		case c <- change:
+		default:
+		}
+	}
+}
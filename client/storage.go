@@ -0,0 +1,166 @@
+package client
+
+import "fmt"
+
+// Storage is the mass storage of the drone, sizes are in MBytes.
+type Storage struct {
+	ID   byte
+	Name string
+	Size uint32
+	Used uint32
+	// Plugged is true if the storage is plugged
+	Plugged bool
+	// Full is true if the storage is full
+	Full bool
+	// Internal is true if the storage is internal to the drone
+	Internal bool
+	// Free space in MBytes
+	Free uint32
+	// RecordingTime left in minutes
+	RecordingTime uint16
+	// PhotosRemaining is the number of photos that can still be taken
+	PhotosRemaining uint32
+	// Remaining is true once Free, RecordingTime and PhotosRemaining have
+	// been reported by the drone
+	Remaining bool
+}
+
+// noRoom returns true if the drone reported that nothing more can be
+// recorded.
+func (s Storage) noRoom() bool {
+	return s.Full || (s.Remaining && s.RecordingTime == 0)
+}
+
+// StorageFullError is returned when the storage of the drone has no room
+// left for a recording.
+type StorageFullError struct {
+	Storage Storage
+}
+
+func (e *StorageFullError) Error() string {
+	return fmt.Sprintf("no room left on storage %q: %d/%d MBytes used", e.Storage.Name, e.Storage.Used, e.Storage.Size)
+}
+
+// Storage returns the mass storage state last reported by the drone.
+func (b *Bebop) Storage() Storage {
+	b.stateLock.RLock()
+	defer b.stateLock.RUnlock()
+
+	return b.storage
+}
+
+// StorageFull returns a channel receiving the storage state every time the
+// drone reports that its storage became full. States are dropped when the
+// channel is not read.
+func (b *Bebop) StorageFull() <-chan Storage {
+	return b.storageFull
+}
+
+// setStorage updates the storage state and notifies StorageFull when the
+// storage became full. It must be called with the stateLock held.
+func (b *Bebop) setStorage(s Storage) {
+	full := !b.storage.noRoom() && s.noRoom()
+	b.storage = s
+
+	if full {
+		select {
+		case b.storageFull <- s:
+		default:
+		}
+	}
+}
+
+func (b *Bebop) decodeCommonState(cmd byte, args []byte) error {
+	//
+	// ARCOMMANDS_Decoder_CommonCommonState*
+	//
+
+	switch cmd {
+	case ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_BATTERYSTATECHANGED:
+		//
+		// uint8 - percent Battery percentage
+		//
+		var percent uint8
+		if err := decodeArgs(args, &percent); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.updateState(StateBattery, func(s *State) { s.Battery = percent })
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_WIFISIGNALCHANGED:
+		//
+		// int16 - rssi RSSI of the signal between controller and the product (in dbm)
+		//
+		var rssi int16
+		if err := decodeArgs(args, &rssi); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		b.updateState(StateRSSI, func(s *State) { s.RSSI = rssi })
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_MASSSTORAGESTATELISTCHANGED:
+		//
+		// uint8 - mass_storage_id Mass storage id (unique)
+		// string - name Mass storage name
+		//
+		var (
+			id   uint8
+			name string
+		)
+		if err := decodeArgs(args, &id, &name); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		s := b.storage
+		s.ID, s.Name = id, name
+		b.setStorage(s)
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_MASSSTORAGEINFOSTATELISTCHANGED:
+		//
+		// uint8 - mass_storage_id Mass storage state id (unique)
+		// uint32 - size Mass storage size in MBytes
+		// uint32 - used_size Mass storage used size in MBytes
+		// uint8 - plugged Mass storage plugged (1 if mass storage is plugged, otherwise 0)
+		// uint8 - full Mass storage full information state (1 if mass storage full, 0 otherwise).
+		// uint8 - internal Mass storage internal type state (1 if mass storage is internal, 0 otherwise)
+		//
+		var (
+			id, plugged, full, internal uint8
+			size, used                  uint32
+		)
+		if err := decodeArgs(args, &id, &size, &used, &plugged, &full, &internal); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		s := b.storage
+		s.ID, s.Size, s.Used = id, size, used
+		s.Plugged, s.Full, s.Internal = plugged == 1, full == 1, internal == 1
+		b.setStorage(s)
+		b.stateLock.Unlock()
+	case ARCOMMANDS_ID_COMMON_COMMONSTATE_CMD_MASSSTORAGEINFOREMAININGLISTCHANGED:
+		//
+		// uint32 - free_space Mass storage free space in MBytes
+		// uint16 - rec_time Mass storage record time reamining in minute
+		// uint32 - photo_remaining Mass storage photo remaining
+		//
+		var (
+			free, photos uint32
+			recording    uint16
+		)
+		if err := decodeArgs(args, &free, &recording, &photos); err != nil {
+			return err
+		}
+
+		b.stateLock.Lock()
+		s := b.storage
+		s.Free, s.RecordingTime, s.PhotosRemaining, s.Remaining = free, recording, photos, true
+		b.setStorage(s)
+		b.stateLock.Unlock()
+	}
+
+	return nil
+}
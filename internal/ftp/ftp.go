@@ -0,0 +1,338 @@
+// Package ftp is a minimal FTP client for the file servers of the Bebop,
+// which only support anonymous passive mode transfers.
+package ftp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Entry is a file or directory of a directory listing.
+type Entry struct {
+	Name string
+	Size int64
+	Dir  bool
+}
+
+// Conn is a connection to an FTP server. Closing the context given to Dial
+// aborts any transfer in progress and closes the connection.
+type Conn struct {
+	ctx  context.Context
+	conn net.Conn
+	text *textproto.Conn
+	done chan struct{}
+
+	lock sync.Mutex
+	data net.Conn
+}
+
+// Dial connects to the FTP server at addr and logs in anonymously.
+func Dial(ctx context.Context, addr string) (*Conn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Conn{
+		ctx:  ctx,
+		conn: conn,
+		text: textproto.NewConn(conn),
+		done: make(chan struct{}),
+	}
+	go c.watch()
+
+	if _, _, err := c.text.ReadResponse(220); err != nil {
+		c.Close()
+		return nil, c.err(err)
+	}
+
+	if err := c.login("anonymous", "anonymous"); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// watch closes the connections when the context is done.
+func (c *Conn) watch() {
+	select {
+	case <-c.ctx.Done():
+		c.lock.Lock()
+		if c.data != nil {
+			c.data.Close()
+		}
+		c.lock.Unlock()
+		c.conn.Close()
+	case <-c.done:
+	}
+}
+
+// err returns the context error when err was caused by the context being
+// done.
+func (c *Conn) err(err error) error {
+	if err != nil && c.ctx.Err() != nil {
+		return c.ctx.Err()
+	}
+	return err
+}
+
+// cmd sends a command and reads the reply, which must start with expect.
+func (c *Conn) cmd(expect int, format string, args ...interface{}) (string, error) {
+	if err := c.text.PrintfLine(format, args...); err != nil {
+		return "", c.err(err)
+	}
+
+	_, msg, err := c.text.ReadResponse(expect)
+	return msg, c.err(err)
+}
+
+func (c *Conn) login(user string, password string) error {
+	if err := c.text.PrintfLine("USER %s", user); err != nil {
+		return c.err(err)
+	}
+
+	code, msg, err := c.text.ReadResponse(0)
+	if err != nil {
+		return c.err(err)
+	}
+
+	switch code {
+	case 230:
+		return nil
+	case 331:
+		_, err := c.cmd(230, "PASS %s", password)
+		return err
+	default:
+		return &textproto.Error{Code: code, Msg: msg}
+	}
+}
+
+// transfer opens a passive data connection and sends a command that
+// transfers a file over it, restarting offset bytes into the file when
+// offset is positive.
+func (c *Conn) transfer(offset int64, format string, args ...interface{}) (net.Conn, error) {
+	if _, err := c.cmd(200, "TYPE I"); err != nil {
+		return nil, err
+	}
+
+	msg, err := c.cmd(227, "PASV")
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := passivePort(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// the address in the reply is ignored, it is the one of the control
+	// connection anyway and can be wrong behind NAT
+	host, _, _ := net.SplitHostPort(c.conn.RemoteAddr().String())
+
+	var d net.Dialer
+	data, err := d.DialContext(c.ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, c.err(err)
+	}
+
+	// the restart marker only applies to the command right after it
+	if offset > 0 {
+		if _, err := c.cmd(350, "REST %d", offset); err != nil {
+			data.Close()
+			return nil, err
+		}
+	}
+
+	c.lock.Lock()
+	c.data = data
+	c.lock.Unlock()
+
+	if _, err := c.cmd(1, format, args...); err != nil {
+		c.lock.Lock()
+		c.data = nil
+		c.lock.Unlock()
+		data.Close()
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// closeData closes the data connection and reads the transfer result.
+func (c *Conn) closeData() error {
+	c.lock.Lock()
+	data := c.data
+	c.data = nil
+	c.lock.Unlock()
+
+	if data == nil {
+		return nil
+	}
+	data.Close()
+
+	_, _, err := c.text.ReadResponse(2)
+	return c.err(err)
+}
+
+// passivePort parses the port of a PASV reply such as
+// "Entering Passive Mode (192,168,42,1,195,80)".
+func passivePort(msg string) (int, error) {
+	start, end := strings.Index(msg, "("), strings.Index(msg, ")")
+	if start < 0 || end < start {
+		return 0, fmt.Errorf("invalid passive mode reply %q", msg)
+	}
+
+	fields := strings.Split(msg[start+1:end], ",")
+	if len(fields) != 6 {
+		return 0, fmt.Errorf("invalid passive mode reply %q", msg)
+	}
+
+	high, err := strconv.Atoi(strings.TrimSpace(fields[4]))
+	if err != nil {
+		return 0, fmt.Errorf("invalid passive mode reply %q", msg)
+	}
+	low, err := strconv.Atoi(strings.TrimSpace(fields[5]))
+	if err != nil {
+		return 0, fmt.Errorf("invalid passive mode reply %q", msg)
+	}
+
+	return high<<8 | low, nil
+}
+
+// Store uploads r to path, replacing any existing file.
+func (c *Conn) Store(path string, r io.Reader) error {
+	data, err := c.transfer(0, "STOR %s", path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(data, r); err != nil {
+		c.closeData()
+		return c.err(err)
+	}
+
+	return c.closeData()
+}
+
+// List returns the entries of the directory at path.
+func (c *Conn) List(path string) ([]Entry, error) {
+	data, err := c.transfer(0, "LIST %s", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(data)
+	for scanner.Scan() {
+		if entry, ok := parseEntry(scanner.Text()); ok {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		c.closeData()
+		return nil, c.err(err)
+	}
+
+	return entries, c.closeData()
+}
+
+// parseEntry parses a line of a LIST reply in the format of "ls -l", such
+// as "-rw-r--r-- 1 root root 1024 Jan 01 00:00 name".
+func parseEntry(line string) (Entry, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 9 {
+		return Entry{}, false
+	}
+
+	size, err := strconv.ParseInt(fields[4], 10, 64)
+	if err != nil {
+		return Entry{}, false
+	}
+
+	// the name is everything after the date, it may contain spaces
+	name := line
+	for i := 0; i < 8; i++ {
+		name = strings.TrimLeft(name, " \t")
+		name = name[strings.IndexAny(name, " \t"):]
+	}
+	name = strings.TrimLeft(name, " \t")
+
+	if name == "." || name == ".." {
+		return Entry{}, false
+	}
+
+	return Entry{Name: name, Size: size, Dir: fields[0][0] == 'd'}, true
+}
+
+// Size returns the size in bytes of the file at path.
+func (c *Conn) Size(path string) (int64, error) {
+	msg, err := c.cmd(213, "SIZE %s", path)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := strconv.ParseInt(strings.TrimSpace(msg), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size reply %q", msg)
+	}
+
+	return size, nil
+}
+
+// Retrieve downloads the file at path starting offset bytes into it. The
+// returned reader must be closed before sending another command.
+func (c *Conn) Retrieve(path string, offset int64) (io.ReadCloser, error) {
+	data, err := c.transfer(offset, "RETR %s", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reader{conn: c, data: data}, nil
+}
+
+// reader reads a file being retrieved.
+type reader struct {
+	conn *Conn
+	data net.Conn
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.data.Read(p)
+	if err != nil && err != io.EOF {
+		err = r.conn.err(err)
+	}
+	return n, err
+}
+
+func (r *reader) Close() error {
+	return r.conn.closeData()
+}
+
+// Delete removes the file at path.
+func (c *Conn) Delete(path string) error {
+	_, err := c.cmd(250, "DELE %s", path)
+	return err
+}
+
+// Close ends the session and closes the connection.
+func (c *Conn) Close() error {
+	select {
+	case <-c.done:
+		return nil
+	default:
+	}
+
+	c.cmd(221, "QUIT")
+	close(c.done)
+
+	return c.text.Close()
+}
@@ -0,0 +1,44 @@
+package ftp
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/platforms/parrot/bebop/internal/ftp/ftptest"
+)
+
+func TestPassivePort(t *testing.T) {
+	port, err := passivePort("Entering Passive Mode (192,168,42,1,195,80)")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, port, 195<<8|80)
+
+	_, err = passivePort("Entering Passive Mode")
+	gobottest.Refute(t, err, nil)
+}
+
+func TestStore(t *testing.T) {
+	s := ftptest.NewServer()
+	defer s.Close()
+
+	c, err := Dial(context.Background(), s.Addr)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, c.Store("plan.mavlink", bytes.NewBufferString("QGC WPL 120\n")), nil)
+	gobottest.Assert(t, c.Close(), nil)
+
+	data, ok := s.File("plan.mavlink")
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, string(data), "QGC WPL 120\n")
+}
+
+func TestDialCanceled(t *testing.T) {
+	s := ftptest.NewServer()
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Dial(ctx, s.Addr)
+	gobottest.Refute(t, err, nil)
+}
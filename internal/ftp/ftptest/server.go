@@ -0,0 +1,240 @@
+// Package ftptest provides an in-memory FTP server standing in for the file
+// servers of the Bebop in tests.
+package ftptest
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Server is an FTP server listening on a local port and storing files in
+// memory. It accepts anonymous logins and passive mode transfers only.
+type Server struct {
+	// Addr is the host:port the server is listening on
+	Addr string
+
+	listener net.Listener
+	lock     sync.Mutex
+	files    map[string][]byte
+}
+
+// NewServer starts a server, it must be stopped with Close.
+func NewServer() *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("ftptest: failed to listen: %v", err))
+	}
+
+	s := &Server{
+		Addr:     listener.Addr().String(),
+		listener: listener,
+		files:    make(map[string][]byte),
+	}
+	go s.serve()
+
+	return s
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	s.listener.Close()
+}
+
+// File returns the content of the file at path.
+func (s *Server) File(path string) ([]byte, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	data, ok := s.files[clean(path)]
+	return data, ok
+}
+
+// Files returns the paths of all files.
+func (s *Server) Files() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var paths []string
+	for path := range s.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+// SetFile creates or replaces the file at path.
+func (s *Server) SetFile(path string, data []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.files[clean(path)] = data
+}
+
+func clean(path string) string {
+	return strings.TrimPrefix(path, "/")
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.session(conn)
+	}
+}
+
+// session handles the commands of one client.
+func (s *Server) session(conn net.Conn) {
+	text := textproto.NewConn(conn)
+	defer text.Close()
+
+	var (
+		passive net.Listener
+		offset  int64
+	)
+	defer func() {
+		if passive != nil {
+			passive.Close()
+		}
+	}()
+
+	text.PrintfLine("220 ftptest ready")
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg := line, ""
+		if i := strings.Index(line, " "); i >= 0 {
+			verb, arg = line[:i], line[i+1:]
+		}
+
+		verb = strings.ToUpper(verb)
+		// the restart marker only applies to the command right after it
+		if verb != "REST" && verb != "RETR" {
+			offset = 0
+		}
+
+		switch verb {
+		case "USER":
+			text.PrintfLine("331 password required")
+		case "PASS":
+			text.PrintfLine("230 logged in")
+		case "TYPE":
+			text.PrintfLine("200 type set")
+		case "PASV":
+			if passive != nil {
+				passive.Close()
+			}
+			passive, err = net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				text.PrintfLine("425 %v", err)
+				continue
+			}
+			port := passive.Addr().(*net.TCPAddr).Port
+			text.PrintfLine("227 Entering Passive Mode (127,0,0,1,%d,%d)", port>>8, port&0xff)
+		case "STOR":
+			data, ok := s.accept(text, passive)
+			if !ok {
+				continue
+			}
+			buf, err := io.ReadAll(data)
+			data.Close()
+			if err != nil {
+				text.PrintfLine("426 %v", err)
+				continue
+			}
+			s.SetFile(arg, buf)
+			text.PrintfLine("226 transfer complete")
+		case "REST":
+			offset, err = strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				text.PrintfLine("501 invalid offset")
+				continue
+			}
+			text.PrintfLine("350 restarting at %d", offset)
+		case "RETR":
+			file, ok := s.File(arg)
+			if !ok {
+				text.PrintfLine("550 %s not found", arg)
+				continue
+			}
+			if offset > int64(len(file)) {
+				offset = int64(len(file))
+			}
+			data, ok := s.accept(text, passive)
+			if !ok {
+				continue
+			}
+			data.Write(file[offset:])
+			data.Close()
+			offset = 0
+			text.PrintfLine("226 transfer complete")
+		case "LIST":
+			data, ok := s.accept(text, passive)
+			if !ok {
+				continue
+			}
+			dir := strings.TrimSuffix(clean(arg), "/") + "/"
+			for _, path := range s.Files() {
+				if !strings.HasPrefix(path, dir) || strings.Contains(path[len(dir):], "/") {
+					continue
+				}
+				file, _ := s.File(path)
+				fmt.Fprintf(data, "-rw-r--r-- 1 root root %d Jan 01 00:00 %s\r\n", len(file), path[len(dir):])
+			}
+			data.Close()
+			text.PrintfLine("226 transfer complete")
+		case "SIZE":
+			file, ok := s.File(arg)
+			if !ok {
+				text.PrintfLine("550 %s not found", arg)
+				continue
+			}
+			text.PrintfLine("213 %d", len(file))
+		case "DELE":
+			s.lock.Lock()
+			_, ok := s.files[clean(arg)]
+			delete(s.files, clean(arg))
+			s.lock.Unlock()
+			if !ok {
+				text.PrintfLine("550 %s not found", arg)
+				continue
+			}
+			text.PrintfLine("250 deleted")
+		case "QUIT":
+			text.PrintfLine("221 bye")
+			return
+		default:
+			text.PrintfLine("502 %s not implemented", verb)
+		}
+	}
+}
+
+// accept waits for the client to open the passive data connection.
+func (s *Server) accept(text *textproto.Conn, passive net.Listener) (net.Conn, bool) {
+	if passive == nil {
+		text.PrintfLine("425 use PASV first")
+		return nil, false
+	}
+
+	text.PrintfLine("150 opening data connection")
+
+	data, err := passive.Accept()
+	if err != nil {
+		text.PrintfLine("425 %v", err)
+		return nil, false
+	}
+
+	return data, true
+}
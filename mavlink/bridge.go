@@ -0,0 +1,387 @@
+package mavlink
+
+import (
+	"context"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot/platforms/parrot/bebop/client"
+)
+
+const (
+	// DefaultInterval is how often the telemetry is sent
+	DefaultInterval = 200 * time.Millisecond
+	// DefaultManualTimeout is how long the last MANUAL_CONTROL is flown
+	// before the drone is stopped
+	DefaultManualTimeout = 500 * time.Millisecond
+	// heartbeatInterval is how often HEARTBEAT is sent
+	heartbeatInterval = time.Second
+	// maxFrameLen is the size of the largest MAVLink v2 frame
+	maxFrameLen = 280
+	// unknownPosition is the latitude and longitude reported by the drone
+	// when it has no GPS position
+	unknownPosition = 500
+)
+
+// Drone is the drone bridged to the ground control station, such as a
+// *client.Bebop.
+type Drone interface {
+	State() client.State
+	TakeOff() error
+	Land() error
+	NavigateHome(start bool) error
+	SetPcmd(pcmd client.Pcmd) error
+}
+
+// Bridge sends the telemetry of a drone to a ground control station and
+// runs the commands it receives from it.
+type Bridge struct {
+	Drone Drone
+	// SystemID and ComponentID identify the drone on the MAVLink network
+	SystemID    byte
+	ComponentID byte
+	// Interval between two telemetry updates, DefaultInterval when zero
+	Interval time.Duration
+	// ManualTimeout is how long the piloting command of the last
+	// MANUAL_CONTROL is kept, the drone stops moving once the station stops
+	// sending them. It is checked every Interval and is DefaultManualTimeout
+	// when zero.
+	ManualTimeout time.Duration
+
+	lock   sync.Mutex
+	seq    byte
+	gcs    net.Addr
+	start  time.Time
+	manual time.Time
+}
+
+// NewBridge returns a bridge for drone.
+func NewBridge(drone Drone) *Bridge {
+	return &Bridge{
+		Drone:         drone,
+		SystemID:      1,
+		ComponentID:   1,
+		Interval:      DefaultInterval,
+		ManualTimeout: DefaultManualTimeout,
+	}
+}
+
+// ListenAndServe listens on the UDP address addr and serves the ground
+// control station at gcs, such as "127.0.0.1:14550" for QGroundControl. When
+// gcs is empty the telemetry is sent to the last station heard from.
+func ListenAndServe(ctx context.Context, drone Drone, addr string, gcs string) error {
+	var gcsAddr net.Addr
+	if gcs != "" {
+		a, err := net.ResolveUDPAddr("udp", gcs)
+		if err != nil {
+			return err
+		}
+		gcsAddr = a
+	}
+
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return NewBridge(drone).Serve(ctx, conn, gcsAddr)
+}
+
+// Serve sends the telemetry to gcs and runs the commands received on conn
+// until ctx is done. When gcs is nil the telemetry is sent to the last
+// station heard from.
+func (b *Bridge) Serve(ctx context.Context, conn net.PacketConn, gcs net.Addr) error {
+	b.lock.Lock()
+	b.gcs, b.start = gcs, time.Now()
+	b.lock.Unlock()
+
+	interval, manualTimeout := b.Interval, b.ManualTimeout
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if manualTimeout <= 0 {
+		manualTimeout = DefaultManualTimeout
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- b.receive(ctx, conn) }()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var heartbeat time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			// unblock the receiver
+			conn.SetReadDeadline(time.Now())
+			<-errs
+			return nil
+		case err := <-errs:
+			return err
+		case now := <-ticker.C:
+			if b.manualLost(now, manualTimeout) {
+				b.Drone.SetPcmd(client.Pcmd{})
+			}
+
+			gcs := b.station()
+			if gcs == nil {
+				continue
+			}
+
+			state := b.Drone.State()
+			messages := b.telemetry(state, now)
+			if now.Sub(heartbeat) >= heartbeatInterval {
+				messages = append([]message{heartbeatOf(state)}, messages...)
+				heartbeat = now
+			}
+
+			for _, m := range messages {
+				if err := b.send(conn, gcs, m); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// station returns the address of the ground control station.
+func (b *Bridge) station() net.Addr {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.gcs
+}
+
+// manualLost returns true once when no MANUAL_CONTROL was received for
+// timeout.
+func (b *Bridge) manualLost(now time.Time, timeout time.Duration) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.manual.IsZero() || now.Sub(b.manual) < timeout {
+		return false
+	}
+
+	b.manual = time.Time{}
+	return true
+}
+
+// send writes the message m to addr.
+func (b *Bridge) send(conn net.PacketConn, addr net.Addr, m message) error {
+	b.lock.Lock()
+	f := Frame{
+		Seq:         b.seq,
+		SystemID:    b.SystemID,
+		ComponentID: b.ComponentID,
+		MessageID:   m.id(),
+		Payload:     m.payload(),
+	}
+	b.seq++
+	b.lock.Unlock()
+
+	buf, err := f.Marshal()
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.WriteTo(buf, addr)
+	return err
+}
+
+// receive runs the commands received on conn until ctx is done.
+func (b *Bridge) receive(ctx context.Context, conn net.PacketConn) error {
+	buf := make([]byte, maxFrameLen)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		f, err := Unmarshal(buf[:n])
+		if err != nil {
+			// other messages and garbage are ignored
+			continue
+		}
+
+		b.lock.Lock()
+		b.gcs = addr
+		b.lock.Unlock()
+
+		if err := b.handle(conn, addr, f); err != nil {
+			return err
+		}
+	}
+}
+
+// handle runs the command of the frame f received from addr.
+func (b *Bridge) handle(conn net.PacketConn, addr net.Addr, f Frame) error {
+	switch f.MessageID {
+	case MSG_ID_COMMAND_LONG:
+		var m CommandLong
+		if err := decode(f.Payload, &m); err != nil {
+			return nil
+		}
+		if !b.targeted(m.TargetSystem) {
+			return nil
+		}
+
+		return b.send(conn, addr, CommandAck{Command: m.Command, Result: b.command(m.Command)})
+	case MSG_ID_MANUAL_CONTROL:
+		var m ManualControl
+		if err := decode(f.Payload, &m); err != nil {
+			return nil
+		}
+		if !b.targeted(m.Target) {
+			return nil
+		}
+
+		b.lock.Lock()
+		b.manual = time.Now()
+		b.lock.Unlock()
+
+		b.Drone.SetPcmd(pcmdOf(m))
+	}
+
+	return nil
+}
+
+// targeted returns true if a message for the system target is for the
+// drone, 0 targets every system.
+func (b *Bridge) targeted(target byte) bool {
+	return target == 0 || target == b.SystemID
+}
+
+// command runs the MAV_CMD command and returns its MAV_RESULT.
+func (b *Bridge) command(command uint16) byte {
+	var err error
+
+	switch command {
+	case MAV_CMD_NAV_TAKEOFF:
+		err = b.Drone.TakeOff()
+	case MAV_CMD_NAV_LAND:
+		err = b.Drone.Land()
+	case MAV_CMD_NAV_RETURN_TO_LAUNCH:
+		err = b.Drone.NavigateHome(true)
+	default:
+		return MAV_RESULT_UNSUPPORTED
+	}
+
+	if err != nil {
+		return MAV_RESULT_FAILED
+	}
+	return MAV_RESULT_ACCEPTED
+}
+
+// axis returns the Pcmd value of a MANUAL_CONTROL axis, INT16_MAX marks an
+// axis without input.
+func axis(v int16) int {
+	if v == math.MaxInt16 {
+		return 0
+	}
+
+	p := int(v) / 10
+	if p > 100 {
+		return 100
+	}
+	if p < -100 {
+		return -100
+	}
+	return p
+}
+
+// pcmdOf returns the piloting command of m, its axes range from -1000 to
+// 1000 and Z, the vertical speed, keeps the altitude at 0.
+func pcmdOf(m ManualControl) client.Pcmd {
+	pcmd := client.Pcmd{
+		Roll:  axis(m.Y),
+		Pitch: axis(m.X),
+		Yaw:   axis(m.R),
+		Gaz:   axis(m.Z),
+	}
+	// like the movement commands of the client, any input flags the pcmd
+	// which also keeps the piloting session going
+	if pcmd.Roll != 0 || pcmd.Pitch != 0 || pcmd.Yaw != 0 || pcmd.Gaz != 0 {
+		pcmd.Flag = 1
+	}
+	return pcmd
+}
+
+// heartbeatOf returns the HEARTBEAT of the drone in state.
+func heartbeatOf(state client.State) Heartbeat {
+	h := Heartbeat{
+		Type:         MAV_TYPE_QUADROTOR,
+		Autopilot:    MAV_AUTOPILOT_GENERIC,
+		BaseMode:     MAV_MODE_FLAG_CUSTOM_MODE_ENABLED | MAV_MODE_FLAG_MANUAL_INPUT_ENABLED,
+		SystemStatus: MAV_STATE_ACTIVE,
+	}
+
+	switch {
+	case state.FlyingState == client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_EMERGENCY:
+		h.SystemStatus = MAV_STATE_EMERGENCY
+	case state.Alert == client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_ALERTSTATECHANGED_STATE_CRITICALBATTERY:
+		h.SystemStatus = MAV_STATE_CRITICAL
+	case state.FlyingState == client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_LANDED:
+		h.SystemStatus = MAV_STATE_STANDBY
+	}
+
+	if state.FlyingState != client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_LANDED {
+		h.BaseMode |= MAV_MODE_FLAG_SAFETY_ARMED
+	}
+
+	return h
+}
+
+// telemetry returns the messages describing state at now.
+func (b *Bridge) telemetry(state client.State, now time.Time) []message {
+	boot := uint32(now.Sub(b.start) / time.Millisecond)
+
+	heading := float64(state.Attitude.Yaw) * 180 / math.Pi
+	if heading < 0 {
+		heading += 360
+	}
+	speed := float32(math.Hypot(float64(state.Speed.X), float64(state.Speed.Y)))
+
+	messages := []message{
+		Attitude{
+			TimeBootMs: boot,
+			Roll:       state.Attitude.Roll,
+			Pitch:      state.Attitude.Pitch,
+			Yaw:        state.Attitude.Yaw,
+		},
+		SysStatus{
+			VoltageBattery:   math.MaxUint16,
+			CurrentBattery:   -1,
+			BatteryRemaining: int8(state.Battery),
+		},
+		VfrHud{
+			Airspeed:    speed,
+			Groundspeed: speed,
+			Alt:         float32(state.Altitude),
+			Climb:       -state.Speed.Z,
+			Heading:     int16(heading),
+		},
+	}
+
+	if state.Position.Latitude != unknownPosition && state.Position.Longitude != unknownPosition {
+		messages = append(messages, GlobalPositionInt{
+			TimeBootMs:  boot,
+			Lat:         int32(state.Position.Latitude * 1e7),
+			Lon:         int32(state.Position.Longitude * 1e7),
+			Alt:         int32(state.Position.Altitude * 1000),
+			RelativeAlt: int32(state.Altitude * 1000),
+			Vx:          int16(state.Speed.X * 100),
+			Vy:          int16(state.Speed.Y * 100),
+			Vz:          int16(state.Speed.Z * 100),
+			Hdg:         uint16(heading*100) % 36000,
+		})
+	}
+
+	return messages
+}
@@ -0,0 +1,359 @@
+// Package mavlink bridges the Bebop to MAVLink ground control stations such
+// as QGroundControl over UDP.
+package mavlink
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	stxV1 = 0xfe
+	stxV2 = 0xfd
+
+	// incompatSigned is set in the incompatibility flags of signed frames
+	incompatSigned = 0x01
+	signatureLen   = 13
+)
+
+// MAVLink message ids.
+const (
+	MSG_ID_HEARTBEAT           uint32 = 0
+	MSG_ID_SYS_STATUS          uint32 = 1
+	MSG_ID_ATTITUDE            uint32 = 30
+	MSG_ID_GLOBAL_POSITION_INT uint32 = 33
+	MSG_ID_MANUAL_CONTROL      uint32 = 69
+	MSG_ID_VFR_HUD             uint32 = 74
+	MSG_ID_COMMAND_LONG        uint32 = 76
+	MSG_ID_COMMAND_ACK         uint32 = 77
+)
+
+// MAVLink enums used by the bridge.
+const (
+	MAV_TYPE_QUADROTOR    byte = 2
+	MAV_AUTOPILOT_GENERIC byte = 0
+
+	MAV_MODE_FLAG_CUSTOM_MODE_ENABLED  byte = 1
+	MAV_MODE_FLAG_MANUAL_INPUT_ENABLED byte = 64
+	MAV_MODE_FLAG_SAFETY_ARMED         byte = 128
+
+	MAV_STATE_STANDBY   byte = 3
+	MAV_STATE_ACTIVE    byte = 4
+	MAV_STATE_CRITICAL  byte = 5
+	MAV_STATE_EMERGENCY byte = 6
+
+	// MAV_CMD_NAV_RETURN_TO_LAUNCH is not taken by the flight plan player,
+	// the bridge runs it as NavigateHome
+	MAV_CMD_NAV_RETURN_TO_LAUNCH uint16 = 20
+	MAV_CMD_NAV_LAND             uint16 = 21
+	MAV_CMD_NAV_TAKEOFF          uint16 = 22
+
+	MAV_RESULT_ACCEPTED    byte = 0
+	MAV_RESULT_UNSUPPORTED byte = 3
+	MAV_RESULT_FAILED      byte = 4
+)
+
+// messageSpec is the CRC_EXTRA and payload length of a message.
+type messageSpec struct {
+	crcExtra byte
+	length   int
+}
+
+var messageSpecs = map[uint32]messageSpec{
+	MSG_ID_HEARTBEAT:           {50, 9},
+	MSG_ID_SYS_STATUS:          {124, 31},
+	MSG_ID_ATTITUDE:            {39, 28},
+	MSG_ID_GLOBAL_POSITION_INT: {104, 28},
+	MSG_ID_MANUAL_CONTROL:      {243, 11},
+	MSG_ID_VFR_HUD:             {20, 20},
+	MSG_ID_COMMAND_LONG:        {152, 33},
+	MSG_ID_COMMAND_ACK:         {143, 3},
+}
+
+var (
+	// ErrInvalidFrame is returned when a frame is truncated or not a MAVLink
+	// frame
+	ErrInvalidFrame = errors.New("mavlink: invalid frame")
+	// ErrChecksum is returned when the checksum of a frame does not match
+	ErrChecksum = errors.New("mavlink: bad checksum")
+	// ErrUnknownMessage is returned for messages the bridge does not know
+	ErrUnknownMessage = errors.New("mavlink: unknown message")
+)
+
+// crc returns the CRC-16/MCRF4XX of data accumulated onto crc, the checksum
+// of MAVLink frames.
+func crc(crc uint16, data ...byte) uint16 {
+	for _, b := range data {
+		tmp := b ^ byte(crc)
+		tmp ^= tmp << 4
+		crc = crc>>8 ^ uint16(tmp)<<8 ^ uint16(tmp)<<3 ^ uint16(tmp)>>4
+	}
+	return crc
+}
+
+// Frame is a MAVLink message along with the system which sent it.
+type Frame struct {
+	Seq         byte
+	SystemID    byte
+	ComponentID byte
+	MessageID   uint32
+	// Payload is zero extended to the length of the message
+	Payload []byte
+}
+
+// Marshal returns the MAVLink v2 frame, the trailing zeros of the payload
+// are truncated as the protocol requires.
+func (f Frame) Marshal() ([]byte, error) {
+	spec, ok := messageSpecs[f.MessageID]
+	if !ok {
+		return nil, ErrUnknownMessage
+	}
+
+	payload := bytes.TrimRight(f.Payload, "\x00")
+	if len(payload) == 0 {
+		payload = []byte{0}
+	}
+
+	buf := []byte{
+		stxV2,
+		byte(len(payload)),
+		0, // incompatibility flags
+		0, // compatibility flags
+		f.Seq,
+		f.SystemID,
+		f.ComponentID,
+		byte(f.MessageID), byte(f.MessageID >> 8), byte(f.MessageID >> 16),
+	}
+	buf = append(buf, payload...)
+
+	sum := crc(0xffff, buf[1:]...)
+	sum = crc(sum, spec.crcExtra)
+
+	return append(buf, byte(sum), byte(sum>>8)), nil
+}
+
+// Unmarshal parses a MAVLink v1 or v2 frame. Signed frames are accepted
+// without checking their signature.
+func Unmarshal(buf []byte) (Frame, error) {
+	var (
+		f          Frame
+		header     int
+		payloadLen int
+	)
+
+	if len(buf) < 8 {
+		return f, ErrInvalidFrame
+	}
+
+	switch buf[0] {
+	case stxV1:
+		header, payloadLen = 6, int(buf[1])
+		f.Seq, f.SystemID, f.ComponentID = buf[2], buf[3], buf[4]
+		f.MessageID = uint32(buf[5])
+	case stxV2:
+		if len(buf) < 12 {
+			return f, ErrInvalidFrame
+		}
+		header, payloadLen = 10, int(buf[1])
+		f.Seq, f.SystemID, f.ComponentID = buf[4], buf[5], buf[6]
+		f.MessageID = uint32(buf[7]) | uint32(buf[8])<<8 | uint32(buf[9])<<16
+	default:
+		return f, ErrInvalidFrame
+	}
+
+	end := header + payloadLen + 2
+	if buf[0] == stxV2 && buf[2]&incompatSigned != 0 {
+		end += signatureLen
+	}
+	if len(buf) < end {
+		return f, ErrInvalidFrame
+	}
+
+	spec, ok := messageSpecs[f.MessageID]
+	if !ok {
+		return f, ErrUnknownMessage
+	}
+
+	sum := crc(0xffff, buf[1:header+payloadLen]...)
+	sum = crc(sum, spec.crcExtra)
+	if binary.LittleEndian.Uint16(buf[header+payloadLen:]) != sum {
+		return f, ErrChecksum
+	}
+
+	f.Payload = make([]byte, spec.length)
+	copy(f.Payload, buf[header:header+payloadLen])
+
+	return f, nil
+}
+
+// message is a MAVLink message.
+type message interface {
+	id() uint32
+	payload() []byte
+}
+
+// encode returns the payload of a message from its fields in wire order.
+func encode(fields ...interface{}) []byte {
+	buf := &bytes.Buffer{}
+	for _, field := range fields {
+		binary.Write(buf, binary.LittleEndian, field)
+	}
+	return buf.Bytes()
+}
+
+// decode reads the fields of a payload in wire order.
+func decode(payload []byte, fields ...interface{}) error {
+	r := bytes.NewReader(payload)
+	for _, field := range fields {
+		if err := binary.Read(r, binary.LittleEndian, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Heartbeat is the HEARTBEAT message.
+type Heartbeat struct {
+	CustomMode   uint32
+	Type         byte
+	Autopilot    byte
+	BaseMode     byte
+	SystemStatus byte
+}
+
+func (m Heartbeat) id() uint32 { return MSG_ID_HEARTBEAT }
+
+func (m Heartbeat) payload() []byte {
+	// the last field is the version of MAVLink, always 3
+	return encode(m.CustomMode, m.Type, m.Autopilot, m.BaseMode, m.SystemStatus, uint8(3))
+}
+
+// SysStatus is the SYS_STATUS message, only the battery is reported.
+type SysStatus struct {
+	// VoltageBattery in mV, UINT16_MAX when unknown
+	VoltageBattery uint16
+	// CurrentBattery in cA, -1 when unknown
+	CurrentBattery int16
+	// BatteryRemaining in percent, -1 when unknown
+	BatteryRemaining int8
+}
+
+func (m SysStatus) id() uint32 { return MSG_ID_SYS_STATUS }
+
+func (m SysStatus) payload() []byte {
+	return encode(
+		uint32(0), uint32(0), uint32(0), // sensors present, enabled and health
+		uint16(0), // load
+		m.VoltageBattery,
+		m.CurrentBattery,
+		uint16(0), uint16(0), // drop rate and errors of the link
+		uint16(0), uint16(0), uint16(0), uint16(0), // errors count
+		m.BatteryRemaining,
+	)
+}
+
+// Attitude is the ATTITUDE message, angles are in radians.
+type Attitude struct {
+	TimeBootMs uint32
+	Roll       float32
+	Pitch      float32
+	Yaw        float32
+	RollSpeed  float32
+	PitchSpeed float32
+	YawSpeed   float32
+}
+
+func (m Attitude) id() uint32 { return MSG_ID_ATTITUDE }
+
+func (m Attitude) payload() []byte {
+	return encode(m)
+}
+
+// GlobalPositionInt is the GLOBAL_POSITION_INT message.
+type GlobalPositionInt struct {
+	TimeBootMs uint32
+	// Lat and Lon in degE7
+	Lat int32
+	Lon int32
+	// Alt above mean sea level and RelativeAlt above home in mm
+	Alt         int32
+	RelativeAlt int32
+	// Vx to the North, Vy to the East and Vz to the ground in cm/s
+	Vx int16
+	Vy int16
+	Vz int16
+	// Hdg is the heading in cdeg, UINT16_MAX when unknown
+	Hdg uint16
+}
+
+func (m GlobalPositionInt) id() uint32 { return MSG_ID_GLOBAL_POSITION_INT }
+
+func (m GlobalPositionInt) payload() []byte {
+	return encode(m)
+}
+
+// VfrHud is the VFR_HUD message.
+type VfrHud struct {
+	// Airspeed and Groundspeed in m/s
+	Airspeed    float32
+	Groundspeed float32
+	// Alt in m
+	Alt float32
+	// Climb rate in m/s
+	Climb float32
+	// Heading in degrees
+	Heading int16
+	// Throttle in percent
+	Throttle uint16
+}
+
+func (m VfrHud) id() uint32 { return MSG_ID_VFR_HUD }
+
+func (m VfrHud) payload() []byte {
+	return encode(m)
+}
+
+// CommandLong is the COMMAND_LONG message.
+type CommandLong struct {
+	Params          [7]float32
+	Command         uint16
+	TargetSystem    byte
+	TargetComponent byte
+	Confirmation    byte
+}
+
+func (m CommandLong) id() uint32 { return MSG_ID_COMMAND_LONG }
+
+func (m CommandLong) payload() []byte {
+	return encode(m)
+}
+
+// CommandAck is the COMMAND_ACK message.
+type CommandAck struct {
+	Command uint16
+	Result  byte
+}
+
+func (m CommandAck) id() uint32 { return MSG_ID_COMMAND_ACK }
+
+func (m CommandAck) payload() []byte {
+	return encode(m)
+}
+
+// ManualControl is the MANUAL_CONTROL message, axes range from -1000 to
+// 1000 and are INT16_MAX when invalid.
+type ManualControl struct {
+	X       int16
+	Y       int16
+	Z       int16
+	R       int16
+	Buttons uint16
+	Target  byte
+}
+
+func (m ManualControl) id() uint32 { return MSG_ID_MANUAL_CONTROL }
+
+func (m ManualControl) payload() []byte {
+	return encode(m)
+}
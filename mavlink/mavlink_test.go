@@ -0,0 +1,223 @@
+package mavlink
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/platforms/parrot/bebop/client"
+)
+
+// testDrone records the commands run by the bridge.
+type testDrone struct {
+	lock    sync.Mutex
+	state   client.State
+	takeOff int
+	land    error
+	home    []bool
+	pcmds   chan client.Pcmd
+}
+
+func (d *testDrone) State() client.State {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.state
+}
+
+func (d *testDrone) TakeOff() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.takeOff++
+	return nil
+}
+
+func (d *testDrone) NavigateHome(start bool) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.home = append(d.home, start)
+	return nil
+}
+
+func (d *testDrone) Land() error                 { return d.land }
+func (d *testDrone) SetPcmd(p client.Pcmd) error { d.pcmds <- p; return nil }
+
+// readMessage reads frames from conn until one with the message id.
+func readMessage(t *testing.T, conn net.PacketConn, id uint32) Frame {
+	buf := make([]byte, maxFrameLen)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("message %d not received: %v", id, err)
+		}
+		f, err := Unmarshal(buf[:n])
+		gobottest.Assert(t, err, nil)
+		if f.MessageID == id {
+			return f
+		}
+	}
+}
+
+func writeMessage(t *testing.T, conn net.PacketConn, addr net.Addr, m message) {
+	buf, err := Frame{SystemID: 255, MessageID: m.id(), Payload: m.payload()}.Marshal()
+	gobottest.Assert(t, err, nil)
+	_, err = conn.WriteTo(buf, addr)
+	gobottest.Assert(t, err, nil)
+}
+
+func TestCRC(t *testing.T) {
+	gobottest.Assert(t, crc(0xffff, []byte("123456789")...), uint16(0x6f91))
+}
+
+func TestFrameRoundTrip(t *testing.T) {
+	m := CommandAck{Command: MAV_CMD_NAV_TAKEOFF, Result: MAV_RESULT_ACCEPTED}
+	buf, err := Frame{Seq: 7, SystemID: 1, ComponentID: 1, MessageID: m.id(), Payload: m.payload()}.Marshal()
+	gobottest.Assert(t, err, nil)
+	// the trailing zeros are truncated
+	gobottest.Assert(t, int(buf[1]), 1)
+
+	f, err := Unmarshal(buf)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, f.Seq, byte(7))
+	gobottest.Assert(t, f.Payload, []byte{22, 0, 0})
+
+	buf[len(buf)-1]++
+	_, err = Unmarshal(buf)
+	gobottest.Assert(t, err, ErrChecksum)
+}
+
+func TestBridge(t *testing.T) {
+	gcs, err := net.ListenPacket("udp", "127.0.0.1:0")
+	gobottest.Assert(t, err, nil)
+	defer gcs.Close()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	gobottest.Assert(t, err, nil)
+	defer conn.Close()
+
+	drone := &testDrone{
+		state: client.State{
+			FlyingState: client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_LANDED,
+			Battery:     80,
+			Position:    client.Location{Latitude: 48.878, Longitude: 2.367, Altitude: 52.1},
+		},
+		land:  errors.New("land"),
+		pcmds: make(chan client.Pcmd, 1),
+	}
+	b := NewBridge(drone)
+	b.Interval = 10 * time.Millisecond
+	b.ManualTimeout = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- b.Serve(ctx, conn, gcs.LocalAddr()) }()
+
+	var h Heartbeat
+	f := readMessage(t, gcs, MSG_ID_HEARTBEAT)
+	gobottest.Assert(t, decode(f.Payload, &h), nil)
+	gobottest.Assert(t, h.Type, MAV_TYPE_QUADROTOR)
+	gobottest.Assert(t, h.SystemStatus, MAV_STATE_STANDBY)
+
+	var p GlobalPositionInt
+	f = readMessage(t, gcs, MSG_ID_GLOBAL_POSITION_INT)
+	gobottest.Assert(t, decode(f.Payload, &p), nil)
+	gobottest.Assert(t, p.Lat, int32(488780000))
+	gobottest.Assert(t, p.Alt, int32(52100))
+
+	var ack CommandAck
+	writeMessage(t, gcs, conn.LocalAddr(), CommandLong{Command: MAV_CMD_NAV_TAKEOFF, TargetSystem: 1})
+	f = readMessage(t, gcs, MSG_ID_COMMAND_ACK)
+	gobottest.Assert(t, decode(f.Payload, &ack), nil)
+	gobottest.Assert(t, ack, CommandAck{Command: MAV_CMD_NAV_TAKEOFF, Result: MAV_RESULT_ACCEPTED})
+	drone.lock.Lock()
+	gobottest.Assert(t, drone.takeOff, 1)
+	drone.lock.Unlock()
+
+	writeMessage(t, gcs, conn.LocalAddr(), CommandLong{Command: MAV_CMD_NAV_LAND})
+	f = readMessage(t, gcs, MSG_ID_COMMAND_ACK)
+	gobottest.Assert(t, decode(f.Payload, &ack), nil)
+	gobottest.Assert(t, ack.Result, MAV_RESULT_FAILED)
+
+	writeMessage(t, gcs, conn.LocalAddr(), CommandLong{Command: MAV_CMD_NAV_RETURN_TO_LAUNCH, TargetSystem: 1})
+	f = readMessage(t, gcs, MSG_ID_COMMAND_ACK)
+	gobottest.Assert(t, decode(f.Payload, &ack), nil)
+	gobottest.Assert(t, ack, CommandAck{Command: MAV_CMD_NAV_RETURN_TO_LAUNCH, Result: MAV_RESULT_ACCEPTED})
+	drone.lock.Lock()
+	gobottest.Assert(t, drone.home, []bool{true})
+	drone.lock.Unlock()
+
+	writeMessage(t, gcs, conn.LocalAddr(), CommandLong{Command: client.MAV_CMD_CONDITION_YAW})
+	f = readMessage(t, gcs, MSG_ID_COMMAND_ACK)
+	gobottest.Assert(t, decode(f.Payload, &ack), nil)
+	gobottest.Assert(t, ack.Result, MAV_RESULT_UNSUPPORTED)
+
+	// another system is not commanded
+	writeMessage(t, gcs, conn.LocalAddr(), ManualControl{X: 1000, Z: 500, Target: 2})
+	writeMessage(t, gcs, conn.LocalAddr(), ManualControl{X: 500, Y: -200, Z: 1000, R: 2000, Target: 1})
+	select {
+	case pcmd := <-drone.pcmds:
+		gobottest.Assert(t, pcmd, client.Pcmd{Flag: 1, Pitch: 50, Roll: -20, Gaz: 100, Yaw: 100})
+	case <-time.After(2 * time.Second):
+		t.Fatal("MANUAL_CONTROL not run")
+	}
+
+	// the drone stops once the station stops sending MANUAL_CONTROL
+	select {
+	case pcmd := <-drone.pcmds:
+		gobottest.Assert(t, pcmd, client.Pcmd{})
+	case <-time.After(2 * time.Second):
+		t.Fatal("MANUAL_CONTROL not timed out")
+	}
+	select {
+	case pcmd := <-drone.pcmds:
+		t.Errorf("unexpected pcmd %v", pcmd)
+	case <-time.After(4 * b.ManualTimeout):
+	}
+
+	cancel()
+	gobottest.Assert(t, <-done, nil)
+}
+
+func TestBridgeDefaults(t *testing.T) {
+	gcs, err := net.ListenPacket("udp", "127.0.0.1:0")
+	gobottest.Assert(t, err, nil)
+	defer gcs.Close()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	gobottest.Assert(t, err, nil)
+	defer conn.Close()
+
+	// a zero Interval and ManualTimeout take the defaults
+	b := &Bridge{Drone: &testDrone{}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- b.Serve(ctx, conn, gcs.LocalAddr()) }()
+
+	readMessage(t, gcs, MSG_ID_HEARTBEAT)
+
+	cancel()
+	gobottest.Assert(t, <-done, nil)
+}
+
+func TestPcmdOf(t *testing.T) {
+	// invalid axes and a zero Z give no input
+	gobottest.Assert(t, pcmdOf(ManualControl{
+		X: math.MaxInt16,
+		Y: math.MaxInt16,
+		Z: math.MaxInt16,
+		R: math.MaxInt16,
+	}), client.Pcmd{})
+	gobottest.Assert(t, pcmdOf(ManualControl{}), client.Pcmd{})
+
+	gobottest.Assert(t, pcmdOf(ManualControl{X: math.MaxInt16, Y: 300, Z: -400, R: -2000}),
+		client.Pcmd{Flag: 1, Roll: 30, Gaz: -40, Yaw: -100})
+	// a station only yawing or climbing is piloting too
+	gobottest.Assert(t, pcmdOf(ManualControl{X: math.MaxInt16, Y: math.MaxInt16, Z: 300, R: 200}),
+		client.Pcmd{Flag: 1, Gaz: 30, Yaw: 20})
+}
@@ -0,0 +1,214 @@
+// Package media lists, downloads and deletes the photos and videos stored
+// on the Bebop through its FTP server.
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gobot.io/x/gobot/platforms/parrot/bebop/internal/ftp"
+)
+
+const (
+	// DefaultDir is where the Bebop stores its media
+	DefaultDir = "internal_000/Bebop_Drone/media"
+	// DefaultPort is the port of the media FTP server
+	DefaultPort = 21
+)
+
+// Types of media files.
+const (
+	Photo = "photo"
+	Video = "video"
+)
+
+// ErrNoVideo is returned by DownloadLatestVideo when the drone stores no
+// video.
+var ErrNoVideo = errors.New("no video stored on the drone")
+
+// File is a media file stored on the drone.
+type File struct {
+	Name string
+	// Size in bytes
+	Size int64
+	// Type is Photo or Video
+	Type string
+}
+
+// Progress is called while downloading with the number of bytes of the file
+// received so far, including those of a resumed download, and its size.
+type Progress func(done int64, total int64)
+
+// Client accesses the media of a drone.
+type Client struct {
+	// Addr is the host:port of the FTP server
+	Addr string
+	// Dir is the directory holding the media
+	Dir string
+}
+
+// New returns a client for the drone at ip, such as client.Bebop.IP.
+func New(ip string) *Client {
+	return &Client{
+		Addr: net.JoinHostPort(ip, strconv.Itoa(DefaultPort)),
+		Dir:  DefaultDir,
+	}
+}
+
+func (c *Client) path(name string) string {
+	return path.Join(c.Dir, name)
+}
+
+// fileType returns the type of the media file name, or "" if it is not a
+// media file.
+func fileType(name string) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".jpg", ".dng":
+		return Photo
+	case ".mp4":
+		return Video
+	}
+	return ""
+}
+
+// List returns the photos and videos stored on the drone, sorted by name
+// which starts with the date they were taken.
+func (c *Client) List(ctx context.Context) ([]File, error) {
+	conn, err := ftp.Dial(ctx, c.Addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entries, err := conn.List(c.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []File
+	for _, entry := range entries {
+		t := fileType(entry.Name)
+		if entry.Dir || t == "" {
+			continue
+		}
+		files = append(files, File{Name: entry.Name, Size: entry.Size, Type: t})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	return files, nil
+}
+
+// Download saves the media file name to dst. If dst already holds the
+// beginning of the file, for instance after an interrupted download, the
+// download resumes where it stopped. progress may be nil.
+func (c *Client) Download(ctx context.Context, name string, dst string, progress Progress) error {
+	conn, err := ftp.Dial(ctx, c.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	total, err := conn.Size(c.path(name))
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if offset > total {
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		if offset, err = f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	if progress == nil {
+		progress = func(int64, int64) {}
+	}
+	progress(offset, total)
+
+	if offset == total {
+		return nil
+	}
+
+	r, err := conn.Retrieve(c.path(name), offset)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(&progressWriter{w: f, done: offset, total: total, progress: progress}, r)
+	if closeErr := r.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// progressWriter reports the progress of a download.
+type progressWriter struct {
+	w        io.Writer
+	done     int64
+	total    int64
+	progress Progress
+}
+
+func (p *progressWriter) Write(buf []byte) (int, error) {
+	n, err := p.w.Write(buf)
+	p.done += int64(n)
+	p.progress(p.done, p.total)
+	return n, err
+}
+
+// Delete removes the media file name from the drone.
+func (c *Client) Delete(ctx context.Context, name string) error {
+	conn, err := ftp.Dial(ctx, c.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Delete(c.path(name))
+}
+
+// DownloadLatestVideo downloads the video recorded last into dir and
+// returns its path. VideoEventChanged does not carry the name of the file,
+// so this is the newest video stored on the drone, the one recorded by the
+// last StartRecording and StopRecording.
+func (c *Client) DownloadLatestVideo(ctx context.Context, dir string, progress Progress) (string, error) {
+	files, err := c.List(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for i := len(files) - 1; i >= 0; i-- {
+		if files[i].Type != Video {
+			continue
+		}
+
+		dst := filepath.Join(dir, files[i].Name)
+		return dst, c.Download(ctx, files[i].Name, dst, progress)
+	}
+
+	return "", ErrNoVideo
+}
@@ -0,0 +1,79 @@
+package media
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/platforms/parrot/bebop/internal/ftp/ftptest"
+)
+
+func initTestMedia() (*Client, *ftptest.Server) {
+	s := ftptest.NewServer()
+	s.SetFile(DefaultDir+"/Bebop_Drone_2016-10-18T100000+0000_A.mp4", []byte("first video"))
+	s.SetFile(DefaultDir+"/Bebop_Drone_2016-10-18T110000+0000_B.jpg", []byte("photo"))
+	s.SetFile(DefaultDir+"/Bebop_Drone_2016-10-18T120000+0000_C.mp4", []byte("latest video"))
+	s.SetFile(DefaultDir+"/thumb/Bebop_Drone_2016-10-18T120000+0000_C.jpg", []byte("thumbnail"))
+	s.SetFile(DefaultDir+"/notes.txt", []byte("notes"))
+
+	c := New("127.0.0.1")
+	c.Addr = s.Addr
+
+	return c, s
+}
+
+func TestMediaList(t *testing.T) {
+	c, s := initTestMedia()
+	defer s.Close()
+
+	files, err := c.List(context.Background())
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, files, []File{
+		{Name: "Bebop_Drone_2016-10-18T100000+0000_A.mp4", Size: 11, Type: Video},
+		{Name: "Bebop_Drone_2016-10-18T110000+0000_B.jpg", Size: 5, Type: Photo},
+		{Name: "Bebop_Drone_2016-10-18T120000+0000_C.mp4", Size: 12, Type: Video},
+	})
+}
+
+func TestMediaDownloadResume(t *testing.T) {
+	c, s := initTestMedia()
+	defer s.Close()
+
+	dst := filepath.Join(t.TempDir(), "photo.jpg")
+	gobottest.Assert(t, os.WriteFile(dst, []byte("ph"), 0644), nil)
+
+	var calls [][2]int64
+	err := c.Download(context.Background(), "Bebop_Drone_2016-10-18T110000+0000_B.jpg", dst, func(done int64, total int64) {
+		calls = append(calls, [2]int64{done, total})
+	})
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, calls, [][2]int64{{2, 5}, {5, 5}})
+
+	data, _ := os.ReadFile(dst)
+	gobottest.Assert(t, string(data), "photo")
+}
+
+func TestMediaDownloadLatestVideo(t *testing.T) {
+	c, s := initTestMedia()
+	defer s.Close()
+
+	dst, err := c.DownloadLatestVideo(context.Background(), t.TempDir(), nil)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, filepath.Base(dst), "Bebop_Drone_2016-10-18T120000+0000_C.mp4")
+
+	data, _ := os.ReadFile(dst)
+	gobottest.Assert(t, string(data), "latest video")
+}
+
+func TestMediaDelete(t *testing.T) {
+	c, s := initTestMedia()
+	defer s.Close()
+
+	gobottest.Assert(t, c.Delete(context.Background(), "Bebop_Drone_2016-10-18T110000+0000_B.jpg"), nil)
+	_, ok := s.File(DefaultDir + "/Bebop_Drone_2016-10-18T110000+0000_B.jpg")
+	gobottest.Assert(t, ok, false)
+
+	gobottest.Refute(t, c.Delete(context.Background(), "missing.jpg"), nil)
+}
@@ -0,0 +1,170 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gobot.io/x/gobot/platforms/parrot/bebop/client"
+)
+
+const (
+	// DefaultName is the prefix of the names of the log files
+	DefaultName = "telemetry"
+	// DefaultMaxSize is the size in bytes at which a log file is rotated
+	DefaultMaxSize = 10 << 20
+)
+
+// Source is the drone whose telemetry is recorded, such as a *client.Bebop.
+type Source interface {
+	SubscribeSamples() (<-chan client.State, func())
+	CurrentPcmd() client.Pcmd
+}
+
+// Recorder writes samples to log files in Dir, starting a new file once the
+// current one reaches MaxSize.
+type Recorder struct {
+	Dir    string
+	Name   string
+	Format Format
+	// MaxSize in bytes of a log file, 0 disables the rotation
+	MaxSize int64
+	// MaxFiles is how many log files are kept, 0 keeps all of them
+	MaxFiles int
+
+	file  *os.File
+	size  int64
+	write func(s Sample) error
+}
+
+// NewRecorder returns a recorder writing logs in format to dir.
+func NewRecorder(dir string, format Format) *Recorder {
+	return &Recorder{
+		Dir:     dir,
+		Name:    DefaultName,
+		Format:  format,
+		MaxSize: DefaultMaxSize,
+	}
+}
+
+// countingWriter counts the bytes written to the log file.
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (c countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	*c.n += int64(n)
+	return n, err
+}
+
+// open starts a new log file named after the time of its first sample.
+func (r *Recorder) open(t time.Time) error {
+	if err := os.MkdirAll(r.Dir, 0755); err != nil {
+		return err
+	}
+
+	name := r.Name + "-" + t.UTC().Format("20060102T150405.000000000") + r.Format.ext()
+	f, err := os.OpenFile(filepath.Join(r.Dir, name), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	r.file, r.size = f, 0
+	w := countingWriter{w: f, n: &r.size}
+
+	if r.Format == JSONL {
+		enc := json.NewEncoder(w)
+		r.write = func(s Sample) error { return enc.Encode(s) }
+	} else {
+		enc := csv.NewWriter(w)
+		r.write = func(s Sample) error {
+			enc.Write(s.record())
+			enc.Flush()
+			return enc.Error()
+		}
+		if err := enc.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+
+	return r.prune()
+}
+
+// prune removes the oldest log files beyond MaxFiles.
+func (r *Recorder) prune() error {
+	if r.MaxFiles <= 0 {
+		return nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(r.Dir, r.Name+"-*"+r.Format.ext()))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for len(files) > r.MaxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			return err
+		}
+		files = files[1:]
+	}
+
+	return nil
+}
+
+// Write appends s to the current log file, rotating it when it is full.
+func (r *Recorder) Write(s Sample) error {
+	if r.file != nil && r.MaxSize > 0 && r.size >= r.MaxSize {
+		if err := r.Close(); err != nil {
+			return err
+		}
+	}
+
+	if r.file == nil {
+		if err := r.open(s.Time); err != nil {
+			return err
+		}
+	}
+
+	return r.write(s)
+}
+
+// Close closes the current log file, the next sample starts a new one.
+func (r *Recorder) Close() error {
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Record writes a sample every time src reports telemetry, whether its
+// values changed or not, until ctx is done, then closes r.
+func Record(ctx context.Context, src Source, r *Recorder) error {
+	samples, unsubscribe := src.SubscribeSamples()
+	defer unsubscribe()
+	defer r.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return r.Close()
+		case state, ok := <-samples:
+			if !ok {
+				return r.Close()
+			}
+			if err := r.Write(NewSample(time.Now(), state, src.CurrentPcmd())); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Package telemetry records the telemetry of the Bebop to CSV or JSON Lines
+// files and reads them back for post-flight analysis.
+package telemetry
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"gobot.io/x/gobot/platforms/parrot/bebop/client"
+)
+
+// Format of a telemetry log.
+type Format int
+
+// Formats of telemetry logs.
+const (
+	CSV Format = iota
+	JSONL
+)
+
+// ext returns the file extension of the format.
+func (f Format) ext() string {
+	if f == JSONL {
+		return ".jsonl"
+	}
+	return ".csv"
+}
+
+// Sample is the telemetry of the drone at a point in time along with the
+// piloting command sent to it.
+type Sample struct {
+	Time        time.Time       `json:"time"`
+	FlyingState byte            `json:"flying_state"`
+	Attitude    client.Attitude `json:"attitude"`
+	Speed       client.Speed    `json:"speed"`
+	Altitude    float64         `json:"altitude"`
+	Position    client.Location `json:"position"`
+	GPSFixed    bool            `json:"gps_fixed"`
+	Battery     uint8           `json:"battery"`
+	RSSI        int16           `json:"rssi"`
+	Pcmd        client.Pcmd     `json:"pcmd"`
+}
+
+// NewSample returns the sample of state and pcmd at t.
+func NewSample(t time.Time, state client.State, pcmd client.Pcmd) Sample {
+	return Sample{
+		Time:        t,
+		FlyingState: state.FlyingState,
+		Attitude:    state.Attitude,
+		Speed:       state.Speed,
+		Altitude:    state.Altitude,
+		Position:    state.Position,
+		GPSFixed:    state.GPSFixed,
+		Battery:     state.Battery,
+		RSSI:        state.RSSI,
+		Pcmd:        pcmd,
+	}
+}
+
+// State returns the state of the drone recorded in the sample, its fields
+// are all updated at the time of the sample.
+func (s Sample) State() client.State {
+	return client.State{
+		FlyingState: s.FlyingState,
+		Battery:     s.Battery,
+		GPSFixed:    s.GPSFixed,
+		Position:    s.Position,
+		Attitude:    s.Attitude,
+		Speed:       s.Speed,
+		Altitude:    s.Altitude,
+		RSSI:        s.RSSI,
+		Updated: client.StateTimes{
+			FlyingState: s.Time,
+			Battery:     s.Time,
+			GPSFixed:    s.Time,
+			Position:    s.Time,
+			Attitude:    s.Time,
+			Speed:       s.Time,
+			Altitude:    s.Time,
+			RSSI:        s.Time,
+		},
+	}
+}
+
+// csvHeader are the columns of a CSV log.
+var csvHeader = []string{
+	"time", "flying_state",
+	"roll", "pitch", "yaw",
+	"speed_x", "speed_y", "speed_z",
+	"altitude",
+	"latitude", "longitude", "gps_altitude", "gps_fixed",
+	"battery", "rssi",
+	"pcmd_flag", "pcmd_roll", "pcmd_pitch", "pcmd_yaw", "pcmd_gaz", "pcmd_psi",
+}
+
+func formatFloat32(f float32) string {
+	return strconv.FormatFloat(float64(f), 'g', -1, 32)
+}
+
+func formatFloat64(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// record returns the CSV record of the sample.
+func (s Sample) record() []string {
+	return []string{
+		s.Time.Format(time.RFC3339Nano),
+		strconv.Itoa(int(s.FlyingState)),
+		formatFloat32(s.Attitude.Roll),
+		formatFloat32(s.Attitude.Pitch),
+		formatFloat32(s.Attitude.Yaw),
+		formatFloat32(s.Speed.X),
+		formatFloat32(s.Speed.Y),
+		formatFloat32(s.Speed.Z),
+		formatFloat64(s.Altitude),
+		formatFloat64(s.Position.Latitude),
+		formatFloat64(s.Position.Longitude),
+		formatFloat64(s.Position.Altitude),
+		strconv.FormatBool(s.GPSFixed),
+		strconv.Itoa(int(s.Battery)),
+		strconv.Itoa(int(s.RSSI)),
+		strconv.Itoa(s.Pcmd.Flag),
+		strconv.Itoa(s.Pcmd.Roll),
+		strconv.Itoa(s.Pcmd.Pitch),
+		strconv.Itoa(s.Pcmd.Yaw),
+		strconv.Itoa(s.Pcmd.Gaz),
+		formatFloat32(s.Pcmd.Psi),
+	}
+}
+
+// csvParser parses the fields of a CSV record, keeping the first error.
+type csvParser struct {
+	err error
+}
+
+func (p *csvParser) int(field string, bits int) int64 {
+	v, err := strconv.ParseInt(field, 10, bits)
+	if p.err == nil {
+		p.err = err
+	}
+	return v
+}
+
+func (p *csvParser) uint(field string, bits int) uint64 {
+	v, err := strconv.ParseUint(field, 10, bits)
+	if p.err == nil {
+		p.err = err
+	}
+	return v
+}
+
+func (p *csvParser) float(field string, bits int) float64 {
+	v, err := strconv.ParseFloat(field, bits)
+	if p.err == nil {
+		p.err = err
+	}
+	return v
+}
+
+func (p *csvParser) bool(field string) bool {
+	v, err := strconv.ParseBool(field)
+	if p.err == nil {
+		p.err = err
+	}
+	return v
+}
+
+// parseRecord parses a CSV record written by record.
+func parseRecord(record []string) (Sample, error) {
+	if len(record) != len(csvHeader) {
+		return Sample{}, fmt.Errorf("telemetry: %d fields instead of %d", len(record), len(csvHeader))
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, record[0])
+	if err != nil {
+		return Sample{}, err
+	}
+
+	var p csvParser
+	s := Sample{
+		Time:        t,
+		FlyingState: byte(p.uint(record[1], 8)),
+		Attitude: client.Attitude{
+			Roll:  float32(p.float(record[2], 32)),
+			Pitch: float32(p.float(record[3], 32)),
+			Yaw:   float32(p.float(record[4], 32)),
+		},
+		Speed: client.Speed{
+			X: float32(p.float(record[5], 32)),
+			Y: float32(p.float(record[6], 32)),
+			Z: float32(p.float(record[7], 32)),
+		},
+		Altitude: p.float(record[8], 64),
+		Position: client.Location{
+			Latitude:  p.float(record[9], 64),
+			Longitude: p.float(record[10], 64),
+			Altitude:  p.float(record[11], 64),
+		},
+		GPSFixed: p.bool(record[12]),
+		Battery:  uint8(p.uint(record[13], 8)),
+		RSSI:     int16(p.int(record[14], 16)),
+		Pcmd: client.Pcmd{
+			Flag:  int(p.int(record[15], 0)),
+			Roll:  int(p.int(record[16], 0)),
+			Pitch: int(p.int(record[17], 0)),
+			Yaw:   int(p.int(record[18], 0)),
+			Gaz:   int(p.int(record[19], 0)),
+			Psi:   float32(p.float(record[20], 32)),
+		},
+	}
+
+	return s, p.err
+}
+
+// Read reads the samples of a log in format f.
+func Read(r io.Reader, f Format) ([]Sample, error) {
+	var samples []Sample
+
+	if f == JSONL {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			if len(scanner.Bytes()) == 0 {
+				continue
+			}
+
+			var s Sample
+			if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+				return nil, err
+			}
+			samples = append(samples, s)
+		}
+		return samples, scanner.Err()
+	}
+
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, record := range records {
+		// every file starts with the header
+		if i == 0 && len(record) > 0 && record[0] == csvHeader[0] {
+			continue
+		}
+
+		s, err := parseRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, s)
+	}
+
+	return samples, nil
+}
+
+// ReadFile reads the samples of the log at path, its format is given by
+// its extension.
+func ReadFile(path string) ([]Sample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	format := CSV
+	if filepath.Ext(path) == JSONL.ext() {
+		format = JSONL
+	}
+
+	return Read(f, format)
+}
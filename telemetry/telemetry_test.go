@@ -0,0 +1,94 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/platforms/parrot/bebop/client"
+)
+
+// testSource is a drone sending the samples written to samples.
+type testSource struct {
+	samples chan client.State
+	pcmd    client.Pcmd
+}
+
+func (s *testSource) SubscribeSamples() (<-chan client.State, func()) {
+	return s.samples, func() {}
+}
+
+func (s *testSource) CurrentPcmd() client.Pcmd { return s.pcmd }
+
+func testSample(i int) Sample {
+	return Sample{
+		Time:        time.Date(2016, 10, 18, 10, 0, i, 500, time.UTC),
+		FlyingState: client.ARCOMMANDS_ARDRONE3_PILOTINGSTATE_FLYINGSTATECHANGED_STATE_HOVERING,
+		Attitude:    client.Attitude{Roll: 0.1, Pitch: -0.2, Yaw: 3.14},
+		Speed:       client.Speed{X: 1.5, Y: 0, Z: -0.25},
+		Altitude:    float64(i) + 0.5,
+		Position:    client.Location{Latitude: 48.878, Longitude: 2.367, Altitude: 52.1},
+		GPSFixed:    true,
+		Battery:     uint8(90 - i),
+		RSSI:        -42,
+		Pcmd:        client.Pcmd{Flag: 1, Pitch: 20, Gaz: -10, Psi: 0.5},
+	}
+}
+
+func TestRecordCSV(t *testing.T) {
+	dir := t.TempDir()
+	want := testSample(0)
+	src := &testSource{samples: make(chan client.State), pcmd: want.Pcmd}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- Record(ctx, src, NewRecorder(dir, CSV)) }()
+
+	// unchanged samples are recorded too
+	src.samples <- want.State()
+	src.samples <- want.State()
+	cancel()
+	gobottest.Assert(t, <-done, nil)
+
+	files, _ := filepath.Glob(filepath.Join(dir, DefaultName+"-*.csv"))
+	gobottest.Assert(t, len(files), 1)
+
+	samples, err := ReadFile(files[0])
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(samples), 2)
+	gobottest.Refute(t, samples[0].Time, time.Time{})
+	samples[0].Time = want.Time
+	gobottest.Assert(t, samples[0], want)
+}
+
+func TestRecorderRotation(t *testing.T) {
+	dir := t.TempDir()
+	r := NewRecorder(dir, JSONL)
+	r.MaxSize = 1
+	r.MaxFiles = 2
+
+	for i := 0; i < 3; i++ {
+		gobottest.Assert(t, r.Write(testSample(i)), nil)
+	}
+	gobottest.Assert(t, r.Close(), nil)
+
+	files, _ := filepath.Glob(filepath.Join(dir, DefaultName+"-*.jsonl"))
+	gobottest.Assert(t, len(files), 2)
+
+	for i, file := range files {
+		samples, err := ReadFile(file)
+		gobottest.Assert(t, err, nil)
+		gobottest.Assert(t, samples, []Sample{testSample(i + 1)})
+	}
+}
+
+func TestReadInvalidCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.csv")
+	os.WriteFile(path, []byte("2016-10-18T10:00:00Z,2\n"), 0644)
+
+	_, err := ReadFile(path)
+	gobottest.Refute(t, err, nil)
+}
@@ -1,5 +1,12 @@
 package bebop
 
+import (
+	"context"
+	"io"
+
+	"gobot.io/x/gobot/platforms/parrot/bebop/client"
+)
+
 type testDrone struct{}
 
 func (t testDrone) TakeOff() error                    { return nil }
@@ -21,3 +28,211 @@ func (t testDrone) HullProtection(protect bool) error { return nil }
 func (t testDrone) Outdoor(outdoor bool) error        { return nil }
 func (t testDrone) VideoEnable(enable bool) error     { return nil }
 func (t testDrone) VideoStreamMode(mode int8) error   { return nil }
+
+func (t testDrone) CameraOrientation(tilt int8, pan int8) error         { return nil }
+func (t testDrone) CameraOrientationV2(tilt float32, pan float32) error { return nil }
+func (t testDrone) CameraVelocity(tilt float32, pan float32) error      { return nil }
+func (t testDrone) CameraState() client.CameraState                     { return client.CameraState{} }
+
+func (t testDrone) TakePicture() error { return nil }
+func (t testDrone) TakePictureAndWait(ctx context.Context) (client.PictureEvent, error) {
+	return client.PictureEvent{}, nil
+}
+func (t testDrone) PictureState() client.PictureState { return client.PictureState{} }
+
+func (t testDrone) VideoState() client.VideoState { return client.VideoState{} }
+
+func (t testDrone) PilotingSettings() client.PilotingSettings { return client.PilotingSettings{} }
+func (t testDrone) SetMaxAltitude(altitude float32) (client.FloatSetting, error) {
+	return client.FloatSetting{Current: altitude}, nil
+}
+func (t testDrone) SetMaxTilt(tilt float32) (client.FloatSetting, error) {
+	return client.FloatSetting{Current: tilt}, nil
+}
+func (t testDrone) SetMaxDistance(distance float32) (client.FloatSetting, error) {
+	return client.FloatSetting{Current: distance}, nil
+}
+func (t testDrone) SetAbsolutControl(on bool) (bool, error)       { return on, nil }
+func (t testDrone) SetNoFlyOverMaxDistance(on bool) (bool, error) { return on, nil }
+func (t testDrone) SetBankedTurn(on bool) (bool, error)           { return on, nil }
+
+func (t testDrone) SpeedSettings() client.SpeedSettings { return client.SpeedSettings{} }
+func (t testDrone) SetMaxVerticalSpeed(speed float32) (client.FloatSetting, error) {
+	return client.FloatSetting{Current: speed}, nil
+}
+func (t testDrone) SetMaxRotationSpeed(speed float32) (client.FloatSetting, error) {
+	return client.FloatSetting{Current: speed}, nil
+}
+func (t testDrone) SetMaxPitchRollRotationSpeed(speed float32) (client.FloatSetting, error) {
+	return client.FloatSetting{Current: speed}, nil
+}
+func (t testDrone) SpeedProfile() client.SpeedProfile           { return client.SpeedProfile{} }
+func (t testDrone) SetSpeedProfile(p client.SpeedProfile) error { return nil }
+
+func (t testDrone) WifiScan(ctx context.Context, band byte) ([]client.WifiNetwork, error) {
+	return nil, nil
+}
+func (t testDrone) AuthorizedChannels(ctx context.Context) ([]client.WifiChannel, error) {
+	return nil, nil
+}
+func (t testDrone) WifiSelection(selection byte, band byte, channel byte) (client.WifiSelection, error) {
+	return client.WifiSelection{Type: selection, Band: band, Channel: channel}, nil
+}
+func (t testDrone) WifiSelectionState() client.WifiSelection { return client.WifiSelection{} }
+func (t testDrone) AutoSelectWifiChannel(ctx context.Context, band byte, outdoor bool) (client.WifiSelection, error) {
+	return client.WifiSelection{}, nil
+}
+
+func (t testDrone) PictureSettings() client.PictureSettings           { return client.PictureSettings{} }
+func (t testDrone) SetPictureSettings(s client.PictureSettings) error { return nil }
+func (t testDrone) SetPictureFormat(format byte) (byte, error)        { return format, nil }
+func (t testDrone) SetAutoWhiteBalance(mode byte) (byte, error)       { return mode, nil }
+func (t testDrone) SetExposition(value float32) (client.FloatSetting, error) {
+	return client.FloatSetting{Current: value}, nil
+}
+func (t testDrone) SetSaturation(value float32) (client.FloatSetting, error) {
+	return client.FloatSetting{Current: value}, nil
+}
+func (t testDrone) SetTimelapse(enabled bool, interval float32) (client.FloatSetting, error) {
+	return client.FloatSetting{Current: interval}, nil
+}
+func (t testDrone) SetVideoAutorecord(enabled bool) (bool, error)    { return enabled, nil }
+func (t testDrone) SetVideoStabilization(mode byte) (byte, error)    { return mode, nil }
+func (t testDrone) SetVideoRecordingMode(mode byte) (byte, error)    { return mode, nil }
+func (t testDrone) SetVideoFramerate(framerate byte) (byte, error)   { return framerate, nil }
+func (t testDrone) SetVideoResolution(resolution byte) (byte, error) { return resolution, nil }
+
+func (t testDrone) ElectricFrequency(frequency byte) (byte, error) { return frequency, nil }
+func (t testDrone) AntiflickeringMode(mode byte) (byte, error)     { return mode, nil }
+func (t testDrone) AntiflickeringState() client.Antiflickering     { return client.Antiflickering{} }
+
+func (t testDrone) GPSSettings() client.GPSSettings { return client.GPSSettings{} }
+func (t testDrone) SetHome(latitude float64, longitude float64, altitude float64) (client.Location, error) {
+	return client.Location{Latitude: latitude, Longitude: longitude, Altitude: altitude}, nil
+}
+func (t testDrone) ResetHome() (client.Location, error)          { return client.Location{}, nil }
+func (t testDrone) HomeType(homeType byte) (byte, error)         { return homeType, nil }
+func (t testDrone) ReturnHomeDelay(delay uint16) (uint16, error) { return delay, nil }
+func (t testDrone) SendControllerGPS(latitude float64, longitude float64, altitude float64, horizontalAccuracy float64, verticalAccuracy float64) error {
+	return nil
+}
+
+func (t testDrone) StartMagnetoCalibration() error { return nil }
+func (t testDrone) AbortMagnetoCalibration() error { return nil }
+func (t testDrone) MagnetoCalibrationState() client.MagnetoCalibration {
+	return client.MagnetoCalibration{}
+}
+func (t testDrone) NextMagnetoCalibrationStep(ctx context.Context, previous client.MagnetoCalibration) (client.MagnetoCalibration, error) {
+	return client.MagnetoCalibration{}, nil
+}
+
+func (t testDrone) UploadFlightPlan(ctx context.Context, plan io.Reader) error { return nil }
+func (t testDrone) StartFlightPlan() error                                     { return nil }
+func (t testDrone) PauseFlightPlan() error                                     { return nil }
+func (t testDrone) StopFlightPlan() error                                      { return nil }
+func (t testDrone) FlightPlanState() client.FlightPlan                         { return client.FlightPlan{} }
+
+func (t testDrone) UploadMission(ctx context.Context, m *client.Mission) error { return nil }
+
+func (t testDrone) Storage() client.Storage            { return client.Storage{} }
+func (t testDrone) StorageFull() <-chan client.Storage { return nil }
+
+func (t testDrone) Product() client.Product { return client.Product{} }
+
+func (t testDrone) AllSettings(ctx context.Context) (client.Settings, error) {
+	return client.Settings{}, nil
+}
+func (t testDrone) SettingsReset() error                       { return nil }
+func (t testDrone) SetProductName(name string) (string, error) { return name, nil }
+func (t testDrone) SetCountry(code string) (string, error)     { return code, nil }
+func (t testDrone) AutoCountry(automatic bool) (bool, error)   { return automatic, nil }
+func (t testDrone) WifiOutdoor(outdoor bool) (bool, error)     { return outdoor, nil }
+
+func (t testDrone) FlyingState() byte                  { return 0 }
+func (t testDrone) Overheat() client.Overheat          { return client.Overheat{} }
+func (t testDrone) Overheated() <-chan client.Overheat { return nil }
+func (t testDrone) SwitchOff() error                   { return nil }
+func (t testDrone) Ventilate() error                   { return nil }
+
+func (t testDrone) IsPiloting(piloting bool) error { return nil }
+
+func (t testDrone) Reboot(ctx context.Context) error { return nil }
+
+func (t testDrone) State() client.State { return client.State{} }
+func (t testDrone) Subscribe() (<-chan client.StateChange, func()) {
+	return nil, func() {}
+}
+
+func (t testDrone) WaitFlyingState(ctx context.Context, states ...byte) (byte, error) {
+	return 0, nil
+}
+func (t testDrone) TakeOffAndWait(ctx context.Context) error { return nil }
+func (t testDrone) LandAndWait(ctx context.Context) error    { return nil }
+
+func (t testDrone) SetAlertPolicy(alert byte, action client.AlertAction) {}
+func (t testDrone) Alerts() (<-chan client.Alert, func()) {
+	return nil, func() {}
+}
+func (t testDrone) NavigateHome(start bool) error { return nil }
+
+// stubDrone is a testDrone whose methods run the matching function, when
+// set, instead of the no-op.
+type stubDrone struct {
+	testDrone
+	land                func() error
+	cameraOrientationV2 func(tilt float32, pan float32) error
+	cameraState         func() client.CameraState
+	setMaxAltitude      func(altitude float32) (client.FloatSetting, error)
+	subscribe           func() (<-chan client.StateChange, func())
+	storageFull         func() <-chan client.Storage
+	alerts              func() (<-chan client.Alert, func())
+}
+
+func (s *stubDrone) Land() error {
+	if s.land == nil {
+		return s.testDrone.Land()
+	}
+	return s.land()
+}
+
+func (s *stubDrone) CameraOrientationV2(tilt float32, pan float32) error {
+	if s.cameraOrientationV2 == nil {
+		return s.testDrone.CameraOrientationV2(tilt, pan)
+	}
+	return s.cameraOrientationV2(tilt, pan)
+}
+
+func (s *stubDrone) CameraState() client.CameraState {
+	if s.cameraState == nil {
+		return s.testDrone.CameraState()
+	}
+	return s.cameraState()
+}
+
+func (s *stubDrone) SetMaxAltitude(altitude float32) (client.FloatSetting, error) {
+	if s.setMaxAltitude == nil {
+		return s.testDrone.SetMaxAltitude(altitude)
+	}
+	return s.setMaxAltitude(altitude)
+}
+
+func (s *stubDrone) Subscribe() (<-chan client.StateChange, func()) {
+	if s.subscribe == nil {
+		return s.testDrone.Subscribe()
+	}
+	return s.subscribe()
+}
+
+func (s *stubDrone) StorageFull() <-chan client.Storage {
+	if s.storageFull == nil {
+		return s.testDrone.StorageFull()
+	}
+	return s.storageFull()
+}
+
+func (s *stubDrone) Alerts() (<-chan client.Alert, func()) {
+	if s.alerts == nil {
+		return s.testDrone.Alerts()
+	}
+	return s.alerts()
+}